@@ -0,0 +1,85 @@
+package readonly
+
+import "unsafe"
+
+// DefaultArenaChunkSize is the chunk size used by a ByteArena with a
+// non-positive chunk size.
+const DefaultArenaChunkSize = 32 << 10
+
+// NewByteArena returns a ByteArena that allocates memory in chunks of
+// chunkSize bytes.
+func NewByteArena(chunkSize int) *ByteArena { return &ByteArena{chunkSize: chunkSize} }
+
+// ByteArena batches the construction of many small ByteSlice values:
+// data is copied into large chunks, so the views share a few
+// allocations instead of making one each.
+// Memory is never written after it has been handed out, so the views
+// stay read-only for their whole life.
+// The zero value is an arena with DefaultArenaChunkSize chunks.
+// A ByteArena is not safe for concurrent use.
+type ByteArena struct {
+	chunkSize int
+	free      []byte   // unused tail of the current chunk.
+	chunks    [][]byte // memory handed out since the last Reset.
+	size      int
+}
+
+// Append returns a read-only copy of p.
+func (a *ByteArena) Append(p []byte) ByteSlice { return a.AppendString(*(*string)(unsafe.Pointer(&p))) }
+
+// AppendString returns a read-only copy of s.
+func (a *ByteArena) AppendString(s string) ByteSlice {
+	if len(s) == 0 {
+		return ByteSlice{}
+	}
+
+	chunkSize := a.chunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultArenaChunkSize
+	}
+
+	var b []byte
+	switch {
+	case len(s) <= len(a.free):
+		b, a.free = a.free[:len(s):len(s)], a.free[len(s):]
+	case len(s) > chunkSize/4:
+		// Large values would waste the rest of the current chunk.
+		b = make([]byte, len(s))
+		a.chunks = append(a.chunks, b)
+	default:
+		a.free = make([]byte, chunkSize)
+		a.chunks = append(a.chunks, a.free)
+		b, a.free = a.free[:len(s):len(s)], a.free[len(s):]
+	}
+
+	a.size += copy(b, s)
+	return ByteSlice{Slice[byte]{b}}
+}
+
+// Len returns the number of bytes handed out since the last Reset.
+func (a *ByteArena) Len() int { return a.size }
+
+// Reset prepares the arena for reuse.
+// Chunks are released rather than recycled, so the views handed out
+// before the Reset remain valid and unchanged: their memory is
+// reclaimed by the garbage collector once they become unreachable.
+// Use Owns to detect views that belong to a previous generation.
+func (a *ByteArena) Reset() { a.free, a.chunks, a.size = nil, nil, 0 }
+
+// Owns reports whether b was returned by the arena since the last
+// Reset, i.e. whether it belongs to the current generation of the
+// arena. Empty views are never owned.
+// It takes time proportional to the number of chunks.
+func (a *ByteArena) Owns(b ByteSlice) bool {
+	if len(b.s) == 0 {
+		return false
+	}
+
+	p := uintptr(unsafe.Pointer(&b.s[0]))
+	for _, c := range a.chunks {
+		if start := uintptr(unsafe.Pointer(&c[0])); start <= p && p < start+uintptr(len(c)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package readonly_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleByteArena() {
+	var a readonly.ByteArena
+
+	buf := []byte("first")
+	first := a.Append(buf)
+	buf[0] = 'F' // doesn't affect the view.
+	second := a.AppendString("second")
+
+	fmt.Println(first, second, a.Len())
+	// Output:
+	// first second 11
+}
+
+func ExampleByteArena_Reset() {
+	var a readonly.ByteArena
+
+	b := a.AppendString("some text")
+	fmt.Println(a.Owns(b))
+
+	a.Reset()
+	c := a.AppendString("other text")
+	fmt.Println(a.Owns(b), a.Owns(c), b)
+	// Output:
+	// true
+	// false true some text
+}
+
+func TestByteArena_AppendString(t *testing.T) {
+	a := readonly.NewByteArena(64)
+
+	var views []readonly.ByteSlice
+	for i := 0; i < 100; i++ {
+		s := strconv.Itoa(i)
+		if i%10 == 0 {
+			s += string(make([]byte, 32)) // larger than a quarter of the chunk.
+		}
+		views = append(views, a.AppendString(s))
+	}
+
+	for i, v := range views {
+		if v.Cap() != v.Len() {
+			t.Fatalf("[%d] view must not expose the rest of the chunk: %d != %d", i, v.Cap(), v.Len())
+		}
+		if !a.Owns(v) {
+			t.Fatalf("[%d] expected view to be owned by the arena", i)
+		}
+		expected := strconv.Itoa(i)
+		if i%10 == 0 {
+			expected += string(make([]byte, 32))
+		}
+		if v.String() != expected {
+			t.Fatalf("[%d] expected %q, got %q", i, expected, v)
+		}
+	}
+
+	if v := a.Append(nil); v.Len() != 0 || a.Owns(v) {
+		t.Fatalf("expected empty view, got %q", v)
+	}
+	if a.Owns(readonly.NewByteSlice("other")) {
+		t.Fatal("expected foreign view not to be owned by the arena")
+	}
+}
+
+func BenchmarkByteArena(b *testing.B) {
+	data := make([][]byte, 1000)
+	for i := range data {
+		data[i] = []byte("value-" + strconv.Itoa(i))
+	}
+
+	b.Run("readonly.ByteArena", func(b *testing.B) {
+		b.ReportAllocs()
+		var a readonly.ByteArena
+		for i := 0; i < b.N; i++ {
+			a.Reset()
+			for _, p := range data {
+				a.Append(p)
+			}
+		}
+	})
+	b.Run("readonly.NewByteSlice+copy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, p := range data {
+				readonly.NewByteSlice(append([]byte(nil), p...))
+			}
+		}
+	})
+}
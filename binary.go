@@ -0,0 +1,149 @@
+package readonly
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// ErrVarintOverflow is returned by Reader.ReadUvarint and
+// Reader.ReadVarint if the value doesn't fit into 64 bits.
+var ErrVarintOverflow = errors.New("readonly: varint overflows a 64-bit integer")
+
+var errNegativeCount = errors.New("readonly.Reader.ReadBytes: negative count")
+
+// The binary decoding methods below don't consume any input if they
+// return an error. They return io.EOF only if the input is empty, and
+// io.ErrUnexpectedEOF if it ends in the middle of a value.
+
+// ReadUvarint reads an unsigned integer encoded by
+// binary.PutUvarint.
+func (r *Reader) ReadUvarint() (uint64, error) {
+	var (
+		x uint64
+		s uint
+	)
+	for i := 0; i < len(r.s) && i < binary.MaxVarintLen64; i++ {
+		b := r.s[i]
+		if b < 0x80 {
+			if i == binary.MaxVarintLen64-1 && b > 1 {
+				return 0, ErrVarintOverflow
+			}
+			r.s = r.s[i+1:]
+			return x | uint64(b)<<s, nil
+		}
+		x |= uint64(b&0x7f) << s
+		s += 7
+	}
+	if len(r.s) == 0 {
+		return 0, io.EOF
+	}
+	if len(r.s) >= binary.MaxVarintLen64 {
+		return 0, ErrVarintOverflow
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+// ReadVarint reads a signed integer encoded by binary.PutVarint.
+func (r *Reader) ReadVarint() (int64, error) {
+	ux, err := r.ReadUvarint()
+	x := int64(ux >> 1)
+	if ux&1 != 0 {
+		x = ^x
+	}
+	return x, err
+}
+
+// ReadUint16 reads a 16-bit unsigned integer in the given byte order.
+func (r *Reader) ReadUint16(order binary.ByteOrder) (uint16, error) {
+	b, err := r.next(2)
+	if err != nil {
+		return 0, err
+	}
+	return order.Uint16(b), nil
+}
+
+// ReadUint32 reads a 32-bit unsigned integer in the given byte order.
+func (r *Reader) ReadUint32(order binary.ByteOrder) (uint32, error) {
+	b, err := r.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return order.Uint32(b), nil
+}
+
+// ReadUint64 reads a 64-bit unsigned integer in the given byte order.
+func (r *Reader) ReadUint64(order binary.ByteOrder) (uint64, error) {
+	b, err := r.next(8)
+	if err != nil {
+		return 0, err
+	}
+	return order.Uint64(b), nil
+}
+
+// ReadFloat32 reads an IEEE 754 single-precision number in the given
+// byte order.
+func (r *Reader) ReadFloat32(order binary.ByteOrder) (float32, error) {
+	u, err := r.ReadUint32(order)
+	return math.Float32frombits(u), err
+}
+
+// ReadFloat64 reads an IEEE 754 double-precision number in the given
+// byte order.
+func (r *Reader) ReadFloat64(order binary.ByteOrder) (float64, error) {
+	u, err := r.ReadUint64(order)
+	return math.Float64frombits(u), err
+}
+
+// ReadBytes reads the next n bytes and returns a view of them without
+// copying.
+func (r *Reader) ReadBytes(n int) (ByteSlice, error) {
+	if n < 0 {
+		return ByteSlice{}, errNegativeCount
+	}
+	if n == 0 {
+		return NewByteSlice(""), nil
+	}
+	b, err := r.next(n)
+	return ByteSlice{Slice[byte]{b}}, err
+}
+
+// ReadLengthPrefixed reads a length with lenFn, and then as many bytes
+// as ReadBytes.
+// If the length has been read but the data is truncated, it returns
+// io.ErrUnexpectedEOF.
+func (r *Reader) ReadLengthPrefixed(lenFn func(r *Reader) (int, error)) (ByteSlice, error) {
+	s := r.s
+	n, err := lenFn(r)
+	if err == nil {
+		var b ByteSlice
+		if b, err = r.ReadBytes(n); err == nil {
+			return b, nil
+		}
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+	}
+	r.s = s
+	return ByteSlice{}, err
+}
+
+// next consumes n > 0 bytes and returns them as a byte slice, which must
+// not be modified.
+func (r *Reader) next(n int) ([]byte, error) {
+	switch {
+	case len(r.s) == 0:
+		return nil, io.EOF
+	case len(r.s) < n:
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	s := r.s[:n]
+	b := *(*[]byte)(unsafe.Pointer(&s))
+	(*reflect.SliceHeader)(unsafe.Pointer(&b)).Cap = n
+	r.s = r.s[n:]
+	return b, nil
+}
@@ -0,0 +1,217 @@
+package readonly_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleReader_ReadLengthPrefixed() {
+	buf := uvarint(5)
+	buf = append(buf, "hello world"...)
+
+	r := readonly.NewReader(buf)
+	b, err := r.ReadLengthPrefixed(func(r *readonly.Reader) (int, error) {
+		n, err := r.ReadUvarint()
+		return int(n), err
+	})
+	fmt.Printf("%q %v %d\n", b, err, r.Len())
+	// Output:
+	// "hello" <nil> 6
+}
+
+func uvarint(x uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutUvarint(buf, x)]
+}
+
+func TestReader_ReadUint(t *testing.T) {
+	r := readonly.NewReader([]byte{0, 1, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 3, 4})
+
+	u16, err := r.ReadUint16(binary.BigEndian)
+	if u16 != 1 || err != nil {
+		t.Fatalf("expected 1, got %d %v", u16, err)
+	}
+	u32, err := r.ReadUint32(binary.BigEndian)
+	if u32 != 2 || err != nil {
+		t.Fatalf("expected 2, got %d %v", u32, err)
+	}
+	u64, err := r.ReadUint64(binary.BigEndian)
+	if u64 != 3 || err != nil {
+		t.Fatalf("expected 3, got %d %v", u64, err)
+	}
+
+	if _, err = r.ReadUint16(binary.BigEndian); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected %q, got %q", io.ErrUnexpectedEOF, err)
+	}
+	if r.Len() != 1 {
+		t.Fatalf("truncated input must not be consumed, got %d bytes left", r.Len())
+	}
+	if b, err := r.ReadBytes(1); b.String() != "\x04" || err != nil {
+		t.Fatalf("expected %q, got %q %v", "\x04", b, err)
+	}
+	if _, err = r.ReadFloat64(binary.BigEndian); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected %q, got %q", io.EOF, err)
+	}
+	if _, err = r.ReadBytes(-1); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestReader_ReadLengthPrefixed(t *testing.T) {
+	lenFn := func(r *readonly.Reader) (int, error) {
+		n, err := r.ReadUint16(binary.LittleEndian)
+		return int(n), err
+	}
+
+	for i, c := range []struct {
+		data []byte
+		err  error
+	}{
+		{nil, io.EOF},
+		{[]byte{1}, io.ErrUnexpectedEOF},
+		{[]byte{1, 0}, io.ErrUnexpectedEOF},
+		{[]byte{2, 0, 'a'}, io.ErrUnexpectedEOF},
+	} {
+		r := readonly.NewReader(c.data)
+		if _, err := r.ReadLengthPrefixed(lenFn); !errors.Is(err, c.err) {
+			t.Fatalf("[%d] expected %q, got %q", i, c.err, err)
+		}
+		if r.Len() != len(c.data) {
+			t.Fatalf("[%d] truncated input must not be consumed, got %d bytes left", i, r.Len())
+		}
+	}
+}
+
+func FuzzReader_ReadUvarint(f *testing.F) {
+	for _, x := range []uint64{0, 1, 127, 128, 1 << 20, math.MaxUint64} {
+		f.Add(uvarint(x))
+	}
+	f.Add(bytes.Repeat([]byte{0xff}, 11))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		br := bytes.NewReader(data)
+		expected, expectedErr := binary.ReadUvarint(br)
+		expectedVarint, _ := binary.ReadVarint(bytes.NewReader(data))
+
+		r := readonly.NewReader(data)
+		actual, err := r.ReadUvarint()
+		switch {
+		case errors.Is(err, readonly.ErrVarintOverflow):
+			if expectedErr == nil || errors.Is(expectedErr, io.EOF) || errors.Is(expectedErr, io.ErrUnexpectedEOF) {
+				t.Fatalf("expected error %v, got %v", expectedErr, err)
+			}
+		case !errors.Is(err, expectedErr):
+			t.Fatalf("expected error %v, got %v", expectedErr, err)
+		}
+		if err != nil {
+			if r.Len() != len(data) {
+				t.Fatalf("input must not be consumed on error")
+			}
+			return
+		}
+		if actual != expected {
+			t.Fatalf("expected %d, got %d", expected, actual)
+		}
+		if r.Len() != br.Len() {
+			t.Fatalf("expected %d bytes left, got %d", br.Len(), r.Len())
+		}
+
+		v, err := readonly.NewReader(data).ReadVarint()
+		if v != expectedVarint || err != nil {
+			t.Fatalf("expected %d, got %d %v", expectedVarint, v, err)
+		}
+	})
+}
+
+func FuzzReader_ReadUint(f *testing.F) {
+	f.Add([]byte{}, false)
+	f.Add([]byte{1, 2, 3}, true)
+	f.Add([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9}, false)
+
+	f.Fuzz(func(t *testing.T, data []byte, big bool) {
+		var order binary.ByteOrder = binary.LittleEndian
+		if big {
+			order = binary.BigEndian
+		}
+
+		var (
+			u16 uint16
+			u32 uint32
+			u64 uint64
+			r   = readonly.NewReader(data)
+		)
+		for i, c := range []struct {
+			expected func() (uint64, error)
+			actual   func() (uint64, error)
+		}{
+			{
+				func() (uint64, error) {
+					err := binary.Read(bytes.NewReader(data), order, &u16)
+					return uint64(u16), err
+				},
+				func() (uint64, error) { v, err := r.ReadUint16(order); return uint64(v), err },
+			},
+			{
+				func() (uint64, error) {
+					err := binary.Read(bytes.NewReader(data), order, &u32)
+					return uint64(u32), err
+				},
+				func() (uint64, error) { v, err := r.ReadUint32(order); return uint64(v), err },
+			},
+			{
+				func() (uint64, error) { err := binary.Read(bytes.NewReader(data), order, &u64); return u64, err },
+				func() (uint64, error) { v, err := r.ReadUint64(order); return v, err },
+			},
+			{
+				func() (uint64, error) {
+					err := binary.Read(bytes.NewReader(data), order, &u32)
+					return uint64(u32), err
+				},
+				func() (uint64, error) { v, err := r.ReadFloat32(order); return uint64(math.Float32bits(v)), err },
+			},
+			{
+				func() (uint64, error) { err := binary.Read(bytes.NewReader(data), order, &u64); return u64, err },
+				func() (uint64, error) { v, err := r.ReadFloat64(order); return math.Float64bits(v), err },
+			},
+		} {
+			readonly.ResetReader(r, data)
+			expected, expectedErr := c.expected()
+			actual, err := c.actual()
+			if !errors.Is(err, expectedErr) {
+				t.Fatalf("[%d] expected error %v, got %v", i, expectedErr, err)
+			}
+			if err == nil && actual != expected {
+				t.Fatalf("[%d] expected %d, got %d", i, expected, actual)
+			}
+		}
+	})
+}
+
+func BenchmarkReader_ReadUint32(b *testing.B) {
+	data := make([]byte, 4*1024)
+
+	b.Run("readonly.Reader", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r := readonly.NewReader(data)
+			for _, err := r.ReadUint32(binary.LittleEndian); err == nil; _, err = r.ReadUint32(binary.LittleEndian) {
+			}
+		}
+	})
+	b.Run("binary.Read", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var v uint32
+			r := readonly.NewReader(data)
+			for err := binary.Read(r, binary.LittleEndian, &v); err == nil; err = binary.Read(r, binary.LittleEndian, &v) {
+			}
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package readonly
+
+// GetBytes equivalent to v := m[key.String()], but never allocates.
+func GetBytes[V any](m Map[string, V], key ByteSlice) V { return m.m[string(key.s)] }
+
+// GetBytes2 equivalent to v, ok := m[key.String()], but never allocates.
+func GetBytes2[V any](m Map[string, V], key ByteSlice) (V, bool) {
+	val, ok := m.m[string(key.s)]
+	return val, ok
+}
+
+// HasBytes equivalent to _, ok := m[key.String()], but never allocates.
+func HasBytes[V any](m Map[string, V], key ByteSlice) bool { _, ok := m.m[string(key.s)]; return ok }
+
+// NewBytesKeyMap returns a BytesKeyMap over m with case-sensitive keys.
+// The map is not copied.
+func NewBytesKeyMap[V any](m map[string]V) BytesKeyMap[V] { return BytesKeyMap[V]{m: m} }
+
+// NewFoldBytesKeyMap returns a BytesKeyMap with ASCII case-insensitive
+// keys, as used by HTTP headers.
+// The keys of m are copied in lower case. If several keys of m differ
+// only in case, an arbitrary one of their values is kept.
+func NewFoldBytesKeyMap[V any](m map[string]V) BytesKeyMap[V] {
+	folded := make(map[string]V, len(m))
+	var long []string
+	for key, val := range m {
+		key = lower(key)
+		if _, ok := folded[key]; !ok && len(key) > maxStackFoldKey {
+			long = append(long, key)
+		}
+		folded[key] = val
+	}
+	return BytesKeyMap[V]{m: folded, long: long, fold: true}
+}
+
+// maxStackFoldKey is the length of the longest key that a
+// case-insensitive lookup lowers on the stack.
+const maxStackFoldKey = 64
+
+// BytesKeyMap wrapper over a string-keyed map that limits the
+// interface to read-only and allows lookups by ByteSlice without
+// allocation.
+type BytesKeyMap[V any] struct {
+	m    map[string]V
+	long []string // keys longer than maxStackFoldKey if fold.
+	fold bool
+}
+
+// Map returns the underlying map. Keys are lower case if the map is
+// case-insensitive.
+func (m BytesKeyMap[V]) Map() Map[string, V] { return Map[string, V]{m: m.m} }
+
+// Len equivalent to len(m).
+func (m BytesKeyMap[V]) Len() int { return len(m.m) }
+
+// Get equivalent to v := m[key].
+func (m BytesKeyMap[V]) Get(key ByteSlice) V { val, _ := m.Get2(key); return val }
+
+// Has equivalent to _, ok := m[key].
+func (m BytesKeyMap[V]) Has(key ByteSlice) bool { _, ok := m.Get2(key); return ok }
+
+// Get2 equivalent to v, ok := m[key].
+func (m BytesKeyMap[V]) Get2(key ByteSlice) (V, bool) {
+	if !m.fold || !hasUpper(key.s) {
+		val, ok := m.m[string(key.s)]
+		return val, ok
+	}
+
+	// Common keys are lowered on the stack, longer ones are compared
+	// with the few long keys of the map, so that the lookup never
+	// allocates.
+	var buf [maxStackFoldKey]byte
+	if len(key.s) <= len(buf) {
+		for i, c := range key.s {
+			buf[i] = toLower(c)
+		}
+		val, ok := m.m[string(buf[:len(key.s)])]
+		return val, ok
+	}
+
+	for _, k := range m.long {
+		if equalFold(k, key.s) {
+			return m.m[k], true
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+func hasUpper(s []byte) bool {
+	for _, c := range s {
+		if 'A' <= c && c <= 'Z' {
+			return true
+		}
+	}
+	return false
+}
+
+func toLower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+func lower(s string) string {
+	b := []byte(s)
+	for i := range b {
+		b[i] = toLower(b[i])
+	}
+	return string(b)
+}
+
+// equalFold reports whether lower-case s and b are equal under ASCII
+// case folding.
+func equalFold(s string, b []byte) bool {
+	if len(s) != len(b) {
+		return false
+	}
+	for i := range b {
+		if s[i] != toLower(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package readonly_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleGetBytes() {
+	m := readonly.NewMap(map[string]int{"key": 1})
+	key := readonly.NewByteSlice([]byte("key"))
+
+	fmt.Println(readonly.GetBytes(m, key))
+	fmt.Println(readonly.GetBytes2(m, key))
+	fmt.Println(readonly.HasBytes(m, readonly.NewByteSlice("other")))
+	// Output:
+	// 1
+	// 1 true
+	// false
+}
+
+func ExampleNewFoldBytesKeyMap() {
+	m := readonly.NewFoldBytesKeyMap(map[string]string{"Content-Type": "text/plain"})
+
+	fmt.Println(m.Get(readonly.NewByteSlice("content-type")))
+	fmt.Println(m.Get(readonly.NewByteSlice("CONTENT-TYPE")))
+	fmt.Println(m.Has(readonly.NewByteSlice("Content-Length")))
+	// Output:
+	// text/plain
+	// text/plain
+	// false
+}
+
+func TestBytesKeyMap_Get2(t *testing.T) {
+	long := strings.Repeat("X-Long-Header-", 10)
+	src := map[string]int{"Accept": 1, "content-type": 2, long: 3}
+
+	for i, c := range []struct {
+		m   readonly.BytesKeyMap[int]
+		key string
+		val int
+		ok  bool
+	}{
+		{readonly.NewBytesKeyMap(src), "Accept", 1, true},
+		{readonly.NewBytesKeyMap(src), "accept", 0, false},
+		{readonly.NewBytesKeyMap(src), long, 3, true},
+		{readonly.NewFoldBytesKeyMap(src), "accept", 1, true},
+		{readonly.NewFoldBytesKeyMap(src), "ACCEPT", 1, true},
+		{readonly.NewFoldBytesKeyMap(src), "Content-Type", 2, true},
+		{readonly.NewFoldBytesKeyMap(src), strings.ToLower(long), 3, true},
+		{readonly.NewFoldBytesKeyMap(src), strings.ToUpper(long), 3, true},
+		{readonly.NewFoldBytesKeyMap(src), strings.ToUpper(long) + "X", 0, false},
+		{readonly.NewFoldBytesKeyMap(src), strings.ToUpper(long[1:]) + "X", 0, false},
+		{readonly.NewFoldBytesKeyMap(src), "Accept-Encoding", 0, false},
+		{readonly.NewFoldBytesKeyMap[int](nil), "Accept", 0, false},
+	} {
+		val, ok := c.m.Get2(readonly.NewByteSlice(c.key))
+		if val != c.val || ok != c.ok {
+			t.Fatalf("[%d] expected %d %t, got %d %t", i, c.val, c.ok, val, ok)
+		}
+	}
+}
+
+func TestBytesKeyMap_Allocs(t *testing.T) {
+	long := strings.Repeat("X-Long-Header-", 10)
+	src := map[string]int{"Accept": 1, long: 2}
+	m, fm := readonly.NewBytesKeyMap(src), readonly.NewFoldBytesKeyMap(src)
+
+	for i, f := range []func(readonly.ByteSlice){
+		func(key readonly.ByteSlice) { readonly.GetBytes(m.Map(), key) },
+		func(key readonly.ByteSlice) { readonly.GetBytes2(m.Map(), key) },
+		func(key readonly.ByteSlice) { readonly.HasBytes(m.Map(), key) },
+		func(key readonly.ByteSlice) { m.Get(key) },
+		func(key readonly.ByteSlice) { fm.Get(key) },
+	} {
+		for _, key := range []string{"Accept", "ACCEPT", long, strings.ToUpper(long)} {
+			key := readonly.NewByteSlice([]byte(key))
+			if n := testing.AllocsPerRun(100, func() { f(key) }); n != 0 {
+				t.Fatalf("[%d] expected no allocations for %q, got %v", i, key, n)
+			}
+		}
+	}
+}
@@ -1,11 +1,14 @@
 package readonly
 
 import (
+	"errors"
 	"io"
 	"reflect"
 	"unsafe"
 )
 
+var errNegativeOffset = errors.New("readonly: negative offset")
+
 // NewByteSlice constructor for ByteSlice.
 // Accepts a string or slice of bytes as input, avoiding allocations.
 func NewByteSlice[T ~string | ~[]byte](src T) (b ByteSlice) {
@@ -23,11 +26,19 @@ type ByteSlice struct{ Slice[byte] }
 func (b ByteSlice) String() string { return *(*string)(unsafe.Pointer(&b.s)) }
 
 // ReadAt implements io.ReaderAt.
-func (b ByteSlice) ReadAt(p []byte, off int64) (int, error) {
+// Like bytes.Reader, it returns io.EOF if fewer than len(p) bytes
+// are read, and an error if off is negative.
+func (b ByteSlice) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errNegativeOffset
+	}
 	if off >= int64(len(b.s)) {
 		return 0, io.EOF
 	}
-	return copy(p, b.s[off:]), nil
+	if n = copy(p, b.s[off:]); n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
 }
 
 // WriteTo implements io.WriterTo.
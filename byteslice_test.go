@@ -39,6 +39,16 @@ func TestByteSlice_ReadAt(t *testing.T) {
 	if !errors.Is(err, io.EOF) {
 		t.Fatalf("expected %q, got %q", io.EOF, err)
 	}
+
+	p := make([]byte, 2)
+	n, err := r.ReadAt(p, 2)
+	if n != 1 || !errors.Is(err, io.EOF) {
+		t.Fatalf("expected 1 %q, got %d %q", io.EOF, n, err)
+	}
+
+	if _, err = r.ReadAt(p, -1); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
 }
 
 type writer func([]byte) (int, error)
@@ -0,0 +1,101 @@
+package readonly
+
+import "sort"
+
+// Functions returning Slice[Slice[T]] can't be methods of Slice[T],
+// since the type would be instantiated recursively.
+
+// Chunks splits s into consecutive sub-views of n elements, the last
+// one may be shorter. The views share the backing array of s, but
+// can't be resliced beyond their length.
+// Panics if n <= 0.
+func Chunks[T any](s Slice[T], n int) Slice[Slice[T]] {
+	if n <= 0 {
+		panic("readonly.Chunks: non-positive chunk size")
+	}
+	chunks := make([]Slice[T], 0, (len(s.s)+n-1)/n)
+	for i := 0; i < len(s.s); i += n {
+		j := i + n
+		if j > len(s.s) {
+			j = len(s.s)
+		}
+		chunks = append(chunks, Slice[T]{s.s[i:j:j]})
+	}
+	return Slice[Slice[T]]{chunks}
+}
+
+// Windows returns all overlapping sub-views of n consecutive elements
+// of s, sliding by one element. If s is shorter than n, there are no
+// windows.
+// Panics if n <= 0.
+func Windows[T any](s Slice[T], n int) Slice[Slice[T]] {
+	if n <= 0 {
+		panic("readonly.Windows: non-positive window size")
+	}
+	if len(s.s) < n {
+		return Slice[Slice[T]]{}
+	}
+	windows := make([]Slice[T], 0, len(s.s)-n+1)
+	for i := n; i <= len(s.s); i++ {
+		windows = append(windows, Slice[T]{s.s[i-n : i : i]})
+	}
+	return Slice[Slice[T]]{windows}
+}
+
+// SplitFunc splits s into sub-views separated by the elements
+// satisfying sep, like bytes.FieldsFunc, but keeping empty views
+// between adjacent separators, like bytes.Split.
+// Separators are not included in the views.
+func SplitFunc[T any](s Slice[T], sep func(T) bool) Slice[Slice[T]] {
+	var parts []Slice[T]
+	start := 0
+	for i := range s.s {
+		if sep(s.s[i]) {
+			parts = append(parts, Slice[T]{s.s[start:i:i]})
+			start = i + 1
+		}
+	}
+	parts = append(parts, Slice[T]{s.s[start:len(s.s):len(s.s)]})
+	return Slice[Slice[T]]{parts}
+}
+
+// PartitionPoint returns the index of the first element for which
+// pred is false, assuming that s is partitioned: pred is true for
+// all the elements before that index and false after it.
+// It uses binary search, like sort.Search.
+func (s Slice[T]) PartitionPoint(pred func(T) bool) int {
+	return sort.Search(len(s.s), func(i int) bool { return !pred(s.s[i]) })
+}
+
+// GroupBy groups the elements of s by key, preserving their order.
+// If all the elements of a group are adjacent, e.g. s is sorted by key,
+// the group is a sub-view of s, otherwise its elements are copied.
+func GroupBy[T any, K comparable](s Slice[T], key func(T) K) Map[K, Slice[T]] {
+	groups := make(map[K]Slice[T])
+	add := func(k K, i, j int) {
+		if g, ok := groups[k]; ok {
+			// Never appends to the backing array of s, since the views
+			// are capped.
+			groups[k] = Slice[T]{append(g.s, s.s[i:j]...)}
+		} else {
+			groups[k] = Slice[T]{s.s[i:j:j]}
+		}
+	}
+
+	var (
+		start  int
+		runKey K
+	)
+	for i := range s.s {
+		k := key(s.s[i])
+		if i > 0 && k != runKey {
+			add(runKey, start, i)
+			start = i
+		}
+		runKey = k
+	}
+	if len(s.s) > 0 {
+		add(runKey, start, len(s.s))
+	}
+	return Map[K, Slice[T]]{groups}
+}
@@ -0,0 +1,112 @@
+package readonly_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleChunks() {
+	s := readonly.NewSlice([]int{0, 1, 2, 3, 4, 5, 6})
+
+	readonly.Chunks(s, 3).Range(func(i int, chunk readonly.Slice[int]) bool {
+		fmt.Println(chunk.Copy(), chunk.Cap())
+		return true
+	})
+	// Output:
+	// [0 1 2] 3
+	// [3 4 5] 3
+	// [6] 1
+}
+
+func ExampleWindows() {
+	s := readonly.NewSlice([]int{0, 1, 2, 3})
+
+	readonly.Windows(s, 2).Range(func(i int, w readonly.Slice[int]) bool {
+		fmt.Println(w.Copy())
+		return true
+	})
+	// Output:
+	// [0 1]
+	// [1 2]
+	// [2 3]
+}
+
+func ExampleSplitFunc() {
+	s := readonly.NewSlice([]int{1, 0, 2, 3, 0, 0, 4})
+
+	readonly.SplitFunc(s, func(v int) bool { return v == 0 }).Range(func(i int, part readonly.Slice[int]) bool {
+		fmt.Println(part.Copy())
+		return true
+	})
+	// Output:
+	// [1]
+	// [2 3]
+	// []
+	// [4]
+}
+
+func ExampleSlice_PartitionPoint() {
+	s := readonly.NewSlice([]int{1, 3, 5, 7, 9})
+
+	fmt.Println(s.PartitionPoint(func(v int) bool { return v < 6 }))
+	// Output:
+	// 3
+}
+
+func ExampleGroupBy() {
+	s := readonly.NewSlice([]string{"apple", "avocado", "banana", "blueberry", "apricot"})
+
+	groups := readonly.GroupBy(s, func(v string) byte { return v[0] })
+	fmt.Println(groups.Get('a').Copy())
+	fmt.Println(groups.Get('b').Copy())
+	// Output:
+	// [apple avocado apricot]
+	// [banana blueberry]
+}
+
+func TestChunks(t *testing.T) {
+	for size := 0; size < 10; size++ {
+		s := readonly.NewSlice(make([]int, size))
+		for n := 1; n <= size+1; n++ {
+			var total int
+			readonly.Chunks(s, n).Range(func(i int, chunk readonly.Slice[int]) bool {
+				if chunk.Len() == 0 || chunk.Len() > n || chunk.Cap() != chunk.Len() {
+					t.Fatalf("[%d/%d] unexpected chunk %d of length %d", size, n, i, chunk.Len())
+				}
+				total += chunk.Len()
+				return true
+			})
+			if total != size {
+				t.Fatalf("[%d/%d] expected %d elements, got %d", size, n, size, total)
+			}
+
+			if w := readonly.Windows(s, n); size >= n && w.Len() != size-n+1 || size < n && w.Len() != 0 {
+				t.Fatalf("[%d/%d] unexpected number of windows: %d", size, n, w.Len())
+			}
+		}
+	}
+
+	mustPanic(t, func() { readonly.Chunks(readonly.NewSlice([]int{1}), 0) })
+	mustPanic(t, func() { readonly.Windows(readonly.NewSlice([]int{1}), -1) })
+}
+
+func TestGroupBy(t *testing.T) {
+	data := []int{1, 1, 2, 2, 1}
+	groups := readonly.GroupBy(readonly.NewSlice(data), func(v int) int { return v })
+
+	// Group 2 is adjacent and shares memory, group 1 is copied.
+	ones, twos := groups.Get(1), groups.Get(2)
+	data[2], data[0] = 20, 10
+	if twos.Get(0) != 20 || ones.Get(0) != 1 || ones.Len() != 3 {
+		t.Fatalf("unexpected groups: %v %v", ones, twos)
+	}
+	if data[4] != 1 {
+		t.Fatalf("source must not be modified, got %v", data)
+	}
+
+	if g := readonly.GroupBy(readonly.NewSlice[int](nil), func(v int) int { return v }); g.Len() != 0 {
+		t.Fatalf("expected no groups, got %v", g)
+	}
+}
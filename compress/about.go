@@ -0,0 +1,5 @@
+// Package compress provides gzip, zlib and flate compression of
+// read-only byte sequences. Compressed and decompressed data are
+// returned as readonly.ByteSlice values, and the sources are read
+// without copying.
+package compress
@@ -0,0 +1,112 @@
+package compress
+
+import (
+	"container/list"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	"github.com/psyhatter/readonly"
+)
+
+// NewCache returns a Cache that keeps at most maxEntries decompressed
+// views. Values less than 1 mean 1.
+func NewCache(maxEntries int) *Cache {
+	if maxEntries < 1 {
+		maxEntries = 1
+	}
+	return &Cache{limit: maxEntries, lru: list.New(), m: make(map[cacheKey]*list.Element)}
+}
+
+// Cache is a bounded cache of decompressed views keyed by the
+// compressed source, so that the same blob is not decompressed
+// repeatedly. Sources are identified by their memory address and
+// length, which is reliable since the sources are read-only and kept
+// alive by the cache. When the cache is full, the least recently used
+// view is evicted. Errors are not cached.
+// It is safe for concurrent use.
+type Cache struct {
+	mu    sync.Mutex
+	limit int
+	lru   *list.List // of *cacheEntry, most recently used first.
+	m     map[cacheKey]*list.Element
+}
+
+type format uint8
+
+const (
+	formatGzip format = iota
+	formatZlib
+	formatFlate
+)
+
+type cacheKey struct {
+	data uintptr
+	len  int
+	format
+}
+
+type cacheEntry struct {
+	key          cacheKey
+	src, content readonly.ByteSlice // src keeps the key address alive.
+}
+
+// Gunzip is like GunzipTo, but caches the result.
+func (c *Cache) Gunzip(b readonly.ByteSlice) (readonly.ByteSlice, error) {
+	return c.get(b, formatGzip, GunzipTo)
+}
+
+// Unzlib is like UnzlibTo, but caches the result.
+func (c *Cache) Unzlib(b readonly.ByteSlice) (readonly.ByteSlice, error) {
+	return c.get(b, formatZlib, UnzlibTo)
+}
+
+// Inflate is like InflateTo, but caches the result.
+func (c *Cache) Inflate(b readonly.ByteSlice) (readonly.ByteSlice, error) {
+	return c.get(b, formatFlate, InflateTo)
+}
+
+// Len returns the number of cached views.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *Cache) get(
+	b readonly.ByteSlice, f format, decompress func(readonly.ByteSlice) (readonly.ByteSlice, error),
+) (readonly.ByteSlice, error) {
+	s := b.String()
+	key := cacheKey{data: (*reflect.StringHeader)(unsafe.Pointer(&s)).Data, len: len(s), format: f}
+
+	c.mu.Lock()
+	if e, ok := c.m[key]; ok {
+		c.lru.MoveToFront(e)
+		c.mu.Unlock()
+		//nolint:forcetypeassert
+		return e.Value.(*cacheEntry).content, nil
+	}
+	c.mu.Unlock()
+
+	// Decompress without holding the lock, concurrent misses of the
+	// same source may decompress it more than once.
+	content, err := decompress(b)
+	if err != nil {
+		return content, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.m[key]; ok {
+		c.lru.MoveToFront(e)
+		//nolint:forcetypeassert
+		return e.Value.(*cacheEntry).content, nil
+	}
+	c.m[key] = c.lru.PushFront(&cacheEntry{key: key, src: b, content: content})
+	for c.lru.Len() > c.limit {
+		//nolint:forcetypeassert
+		delete(c.m, c.lru.Remove(c.lru.Back()).(*cacheEntry).key)
+	}
+	return content, nil
+}
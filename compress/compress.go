@@ -0,0 +1,79 @@
+package compress
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+
+	"github.com/psyhatter/readonly"
+)
+
+// GzipFrom returns b compressed in gzip format with the default level.
+func GzipFrom(b readonly.ByteSlice) readonly.ByteSlice {
+	var buf bytes.Buffer
+	return compress(&buf, gzip.NewWriter(&buf), b)
+}
+
+// GunzipTo returns b decompressed from gzip format.
+func GunzipTo(b readonly.ByteSlice) (readonly.ByteSlice, error) {
+	r, err := NewGzipReader(b)
+	if err != nil {
+		return readonly.ByteSlice{}, err
+	}
+	return decompress(r, b)
+}
+
+// NewGzipReader returns a reader that decompresses b from gzip format.
+func NewGzipReader(b readonly.ByteSlice) (*gzip.Reader, error) {
+	return gzip.NewReader(readonly.NewReader(b))
+}
+
+// ZlibFrom returns b compressed in zlib format with the default level.
+func ZlibFrom(b readonly.ByteSlice) readonly.ByteSlice {
+	var buf bytes.Buffer
+	return compress(&buf, zlib.NewWriter(&buf), b)
+}
+
+// UnzlibTo returns b decompressed from zlib format.
+func UnzlibTo(b readonly.ByteSlice) (readonly.ByteSlice, error) {
+	r, err := zlib.NewReader(readonly.NewReader(b))
+	if err != nil {
+		return readonly.ByteSlice{}, err
+	}
+	return decompress(r, b)
+}
+
+// FlateFrom returns b compressed in raw deflate format with the
+// default level.
+func FlateFrom(b readonly.ByteSlice) readonly.ByteSlice {
+	var buf bytes.Buffer
+	w, _ := flate.NewWriter(&buf, flate.DefaultCompression) // the level is valid.
+	return compress(&buf, w, b)
+}
+
+// InflateTo returns b decompressed from raw deflate format.
+func InflateTo(b readonly.ByteSlice) (readonly.ByteSlice, error) {
+	return decompress(flate.NewReader(readonly.NewReader(b)), b)
+}
+
+// compress writes b to w and returns the content of buf.
+// Writing to bytes.Buffer never fails, so errors are ignored.
+func compress(buf *bytes.Buffer, w io.WriteCloser, b readonly.ByteSlice) readonly.ByteSlice {
+	_, _ = b.WriteTo(w)
+	_ = w.Close()
+	return readonly.NewByteSlice(buf.Bytes())
+}
+
+func decompress(r io.ReadCloser, src readonly.ByteSlice) (readonly.ByteSlice, error) {
+	var buf bytes.Buffer
+	buf.Grow(2 * src.Len())
+	if _, err := buf.ReadFrom(r); err != nil {
+		return readonly.ByteSlice{}, err
+	}
+	if err := r.Close(); err != nil {
+		return readonly.ByteSlice{}, err
+	}
+	return readonly.NewByteSlice(buf.Bytes()), nil
+}
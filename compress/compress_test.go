@@ -0,0 +1,154 @@
+package compress_test
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/psyhatter/readonly"
+	"github.com/psyhatter/readonly/compress"
+)
+
+func ExampleGzipFrom() {
+	blob := compress.GzipFrom(readonly.NewByteSlice(strings.Repeat("some text ", 100)))
+
+	text, err := compress.GunzipTo(blob)
+	fmt.Println(blob.Len() < text.Len(), text.Len(), err)
+	// Output:
+	// true 1000 <nil>
+}
+
+func ExampleCache() {
+	c := compress.NewCache(16)
+	blob := compress.ZlibFrom(readonly.NewByteSlice("some text"))
+
+	a, _ := c.Unzlib(blob)
+	b, _ := c.Unzlib(blob) // not decompressed again.
+	fmt.Println(a, b, c.Len())
+	// Output:
+	// some text some text 1
+}
+
+func TestRoundTrip(t *testing.T) {
+	for i, data := range []string{"", "a", "some text", strings.Repeat("0123456789", 1<<12)} {
+		b := readonly.NewByteSlice(data)
+		for name, f := range map[string]func() (readonly.ByteSlice, error){
+			"gzip":  func() (readonly.ByteSlice, error) { return compress.GunzipTo(compress.GzipFrom(b)) },
+			"zlib":  func() (readonly.ByteSlice, error) { return compress.UnzlibTo(compress.ZlibFrom(b)) },
+			"flate": func() (readonly.ByteSlice, error) { return compress.InflateTo(compress.FlateFrom(b)) },
+			"gzip reader": func() (readonly.ByteSlice, error) {
+				r, err := compress.NewGzipReader(compress.GzipFrom(b))
+				if err != nil {
+					return readonly.ByteSlice{}, err
+				}
+				actual, err := io.ReadAll(r)
+				return readonly.NewByteSlice(actual), err
+			},
+		} {
+			if actual, err := f(); err != nil || actual.String() != data {
+				t.Fatalf("[%d] %s: expected %d bytes, got %d %v", i, name, len(data), actual.Len(), err)
+			}
+		}
+	}
+
+	for name, f := range map[string]func(readonly.ByteSlice) (readonly.ByteSlice, error){
+		"gzip":  compress.GunzipTo,
+		"zlib":  compress.UnzlibTo,
+		"flate": compress.InflateTo,
+	} {
+		if _, err := f(readonly.NewByteSlice("not compressed")); err == nil {
+			t.Fatalf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestCache(t *testing.T) {
+	c := compress.NewCache(2)
+	blobs := []readonly.ByteSlice{
+		compress.GzipFrom(readonly.NewByteSlice("a")),
+		compress.GzipFrom(readonly.NewByteSlice("b")),
+		compress.GzipFrom(readonly.NewByteSlice("c")),
+	}
+
+	first, _ := c.Gunzip(blobs[0])
+	for _, b := range blobs {
+		_, _ = c.Gunzip(b)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("expected 2 cached views, got %d", c.Len())
+	}
+
+	// The first blob has been evicted, so it is decompressed again.
+	again, _ := c.Gunzip(blobs[0])
+	if again.String() != "a" || sameMemory(first, again) {
+		t.Fatalf("expected a new view of %q, got %q", "a", again)
+	}
+	if cached, _ := c.Gunzip(blobs[0]); !sameMemory(again, cached) {
+		t.Fatal("expected the cached view")
+	}
+
+	// Equal content at a different address is a different source.
+	copied := readonly.NewByteSlice(blobs[2].Copy())
+	a, _ := c.Gunzip(blobs[2])
+	b, _ := c.Gunzip(copied)
+	if sameMemory(a, b) || a.String() != b.String() {
+		t.Fatalf("expected different views of the same content, got %q and %q", a, b)
+	}
+
+	if _, err := c.Inflate(readonly.NewByteSlice("not compressed")); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func sameMemory(a, b readonly.ByteSlice) bool {
+	s1, s2 := a.String(), b.String()
+	return len(s1) == len(s2) &&
+		(*reflect.StringHeader)(unsafe.Pointer(&s1)).Data == (*reflect.StringHeader)(unsafe.Pointer(&s2)).Data
+}
+
+// Run with -race.
+func TestCache_Concurrent(t *testing.T) {
+	c := compress.NewCache(4)
+	blobs := make([]readonly.ByteSlice, 8)
+	for i := range blobs {
+		blobs[i] = compress.FlateFrom(readonly.NewByteSlice(fmt.Sprint(i)))
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				j := i % len(blobs)
+				if b, err := c.Inflate(blobs[j]); err != nil || b.String() != fmt.Sprint(j) {
+					t.Errorf("expected %d, got %q %v", j, b, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkCache(b *testing.B) {
+	blob := compress.GzipFrom(readonly.NewByteSlice(strings.Repeat("some text ", 1<<10)))
+
+	b.Run("GunzipTo", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = compress.GunzipTo(blob)
+		}
+	})
+	b.Run("Cache.Gunzip", func(b *testing.B) {
+		b.ReportAllocs()
+		c := compress.NewCache(1)
+		for i := 0; i < b.N; i++ {
+			_, _ = c.Gunzip(blob)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package readonly_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/psyhatter/readonly"
+)
+
+// Contents used to check that all readers conform to the io interfaces
+// contracts.
+var conformanceContents = []string{
+	"",
+	"a",
+	"some text",
+	"многобайтовый текст 😀",
+	strings.Repeat("0123456789", 1<<10),
+}
+
+func TestConformance_Readers(t *testing.T) {
+	for i, content := range conformanceContents {
+		b := readonly.NewByteSlice(content)
+		half := len(content) / 2
+		b1, b2 := readonly.NewByteSlice(content[:half]), readonly.NewByteSlice(content[half:])
+
+		for name, r := range map[string]io.Reader{
+			"bytes.Reader":            bytes.NewReader([]byte(content)), // reference.
+			"readonly.Reader":         readonly.NewReader(content),
+			"readonly.PosReader":      readonly.NewPosReader(content),
+			"readonly.SectionReader":  b.Section(0, int64(b.Len())),
+			"readonly.MultiReader":    readonly.NewMultiReader(b1, b2),
+			"readonly.Rope.NewReader": readonly.NewRope(b1).Concat(readonly.NewRope(b2)).NewReader(),
+		} {
+			if err := iotest.TestReader(r, []byte(content)); err != nil {
+				t.Fatalf("[%d] %s: %v", i, name, err)
+			}
+		}
+	}
+}
+
+func TestConformance_ReaderAt(t *testing.T) {
+	for i, content := range conformanceContents {
+		b := readonly.NewByteSlice(content)
+		half := len(content) / 2
+		b1, b2 := readonly.NewByteSlice(content[:half]), readonly.NewByteSlice(content[half:])
+		expected := bytes.NewReader([]byte(content))
+
+		for name, r := range map[string]io.ReaderAt{
+			"readonly.ByteSlice":     b,
+			"readonly.SectionReader": b.Section(0, int64(b.Len())),
+			"readonly.MultiReader":   readonly.NewMultiReader(b1, b2),
+		} {
+			offsets := []int64{-1, 0, 1, int64(half), int64(len(content)) - 1, int64(len(content)), int64(len(content)) + 1}
+			for _, off := range offsets {
+				for _, size := range []int{0, 1, 3, len(content), len(content) + 1} {
+					ep, ap := make([]byte, size), make([]byte, size)
+					en, eerr := expected.ReadAt(ep, off)
+					an, aerr := r.ReadAt(ap, off)
+					if en != an || !bytes.Equal(ep, ap) || (eerr == nil) != (aerr == nil) ||
+						errors.Is(eerr, io.EOF) != errors.Is(aerr, io.EOF) {
+						t.Fatalf("[%d] %s.ReadAt(%d, %d): expected %d %v, got %d %v", i, name, size, off, en, eerr, an, aerr)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestConformance_WriterTo(t *testing.T) {
+	for i, content := range conformanceContents {
+		b := readonly.NewByteSlice(content)
+		half := len(content) / 2
+		b1, b2 := readonly.NewByteSlice(content[:half]), readonly.NewByteSlice(content[half:])
+
+		for name, newWriterTo := range map[string]func() io.WriterTo{
+			"bytes.Reader":           func() io.WriterTo { return bytes.NewReader([]byte(content)) }, // reference.
+			"readonly.ByteSlice":     func() io.WriterTo { return b },
+			"readonly.Reader":        func() io.WriterTo { return readonly.NewReader(content) },
+			"readonly.SectionReader": func() io.WriterTo { return b.Section(0, int64(b.Len())) },
+			"readonly.MultiReader":   func() io.WriterTo { return readonly.NewMultiReader(b1, b2) },
+			"readonly.Rope":          func() io.WriterTo { return readonly.NewRope(b1).Concat(readonly.NewRope(b2)) },
+		} {
+			var buf bytes.Buffer
+			n, err := newWriterTo().WriteTo(&buf)
+			if err != nil || n != int64(len(content)) || buf.String() != content {
+				t.Fatalf("[%d] %s: expected %d bytes, got %d %v", i, name, len(content), n, err)
+			}
+
+			var dontWrite writer = func([]byte) (int, error) { return 0, nil }
+			n, err = newWriterTo().WriteTo(dontWrite)
+			if expected := content != ""; n != 0 || errors.Is(err, io.ErrShortWrite) != expected {
+				t.Fatalf("[%d] %s: expected short write %t, got %d %v", i, name, expected, n, err)
+			}
+		}
+	}
+}
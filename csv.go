@@ -0,0 +1,275 @@
+package readonly
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+var errInvalidDelim = errors.New("readonly.CSVReader: invalid field or comment delimiter")
+
+// NewCSVReader returns a new CSVReader reading from src.
+func NewCSVReader[T ~string | ~[]byte | ByteSlice](src T) *CSVReader {
+	return &CSVReader{Comma: ',', s: *(*string)(unsafe.Pointer(&src))}
+}
+
+// CSVReader reads records from CSV data like csv.Reader, but the
+// fields are views of the source data. Only quoted fields with escaped
+// quotes or \r\n line breaks are copied.
+// Parse errors are *csv.ParseError like the ones of csv.Reader.
+type CSVReader struct {
+	// Comma, Comment, FieldsPerRecord and LazyQuotes are the same as
+	// the fields of csv.Reader.
+	Comma           rune
+	Comment         rune
+	FieldsPerRecord int
+	LazyQuotes      bool
+
+	s                    string
+	pos, line, lineStart int
+	end, next            int  // content end of the current line and start of the next one.
+	newline              bool // the current line ends with \n.
+}
+
+// Read reads one record from r. The record is a view of fields. If
+// the record has an unexpected number of fields, Read returns the
+// record along with the error csv.ErrFieldCount. If there is no data
+// left to be read, Read returns an empty Slice, io.EOF.
+func (r *CSVReader) Read() (record Slice[ByteSlice], err error) {
+	if r.Comma == r.Comment || !validDelim(r.Comma) || r.Comment != 0 && !validDelim(r.Comment) {
+		return Slice[ByteSlice]{}, errInvalidDelim
+	}
+
+	comma, comment := string(r.Comma), ""
+	if r.Comment != 0 {
+		comment = string(r.Comment)
+	}
+
+	// Skip empty lines and comments.
+	for {
+		if r.pos >= len(r.s) {
+			return Slice[ByteSlice]{}, io.EOF
+		}
+		r.startLine(r.pos)
+		if r.pos != r.end && (comment == "" || !strings.HasPrefix(r.s[r.pos:], comment)) {
+			break
+		}
+		r.pos = r.next
+	}
+
+	var fields []ByteSlice
+	if r.FieldsPerRecord > 0 {
+		fields = make([]ByteSlice, 0, r.FieldsPerRecord)
+	}
+	recLine := r.line
+	fields, err = r.readFields(fields, recLine, comma)
+	r.pos = r.next
+
+	if r.FieldsPerRecord > 0 {
+		if len(fields) != r.FieldsPerRecord && err == nil {
+			err = &csv.ParseError{StartLine: recLine, Line: recLine, Column: 1, Err: csv.ErrFieldCount}
+		}
+	} else if r.FieldsPerRecord == 0 {
+		r.FieldsPerRecord = len(fields)
+	}
+	return Slice[ByteSlice]{fields}, err
+}
+
+// ReadAll reads all the remaining records from r.
+// A successful call returns err == nil, not err == io.EOF.
+func (r *CSVReader) ReadAll() (records Slice[Slice[ByteSlice]], err error) {
+	var s []Slice[ByteSlice]
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return Slice[Slice[ByteSlice]]{s}, nil
+		}
+		if err != nil {
+			return Slice[Slice[ByteSlice]]{}, err
+		}
+		s = append(s, record)
+	}
+}
+
+// LoadCSVTable reads all the remaining records from r into a Table.
+// The first record is the header with the names of the columns, which
+// are views of the source data like the fields, and are sortable in
+// byte order.
+func LoadCSVTable(r *CSVReader) (Table, error) {
+	if r.FieldsPerRecord < 0 {
+		r.FieldsPerRecord = 0
+	}
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return NewTable()
+	}
+	if err != nil {
+		return Table{}, err
+	}
+
+	values := make([][]ByteSlice, header.Len())
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Table{}, err
+		}
+		for i := range values {
+			values[i] = append(values[i], record.s[i])
+		}
+	}
+
+	cols := make([]Column, len(values))
+	for i := range cols {
+		cols[i] = NewColumnFunc(header.s[i].String(), Slice[ByteSlice]{values[i]}, lessByteSlice)
+	}
+	return NewTable(cols...)
+}
+
+func lessByteSlice(a, b ByteSlice) bool { return a.String() < b.String() }
+
+func validDelim(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+// startLine sets the bounds of the line starting with pos the same
+// way csv.Reader normalizes them: \r\n is \n, \r before EOF is dropped.
+func (r *CSVReader) startLine(pos int) {
+	r.line++
+	r.lineStart = pos
+	i := strings.IndexByte(r.s[pos:], '\n')
+	switch {
+	case i >= 0:
+		r.end, r.next, r.newline = pos+i, pos+i+1, true
+		if i > 0 && r.s[r.end-1] == '\r' {
+			r.end--
+		}
+	default:
+		r.end, r.next, r.newline = len(r.s), len(r.s), false
+		if r.end > pos && r.s[r.end-1] == '\r' {
+			r.end--
+		}
+	}
+}
+
+// normalized reports whether the raw bytes of the current line are the
+// same as the normalized ones.
+func (r *CSVReader) normalized() bool { return r.next-r.end == 1 && r.newline || r.next == r.end }
+
+// readFields appends the fields of the record starting with r.pos to
+// dst.
+func (r *CSVReader) readFields(dst []ByteSlice, recLine int, comma string) ([]ByteSlice, error) {
+	pos := r.pos
+parseField:
+	for {
+		if pos == r.end || r.s[pos] != '"' {
+			// Non-quoted field.
+			i := strings.Index(r.s[pos:r.end], comma)
+			end := r.end
+			if i >= 0 {
+				end = pos + i
+			}
+			if !r.LazyQuotes {
+				if j := strings.IndexByte(r.s[pos:end], '"'); j >= 0 {
+					return dst, &csv.ParseError{StartLine: recLine, Line: r.line, Column: pos + j - r.lineStart + 1, Err: csv.ErrBareQuote}
+				}
+			}
+			dst = append(dst, NewByteSlice(r.s[pos:end]))
+			if i < 0 {
+				return dst, nil
+			}
+			pos = end + len(comma)
+			continue
+		}
+
+		// Quoted field. Until there is something to unescape, it's
+		// a view of r.s[start:end], otherwise it's copied into buf.
+		pos++
+		start, end := pos, pos
+		var buf []byte
+		appendRaw := func(to int) {
+			if buf != nil {
+				buf = append(buf, r.s[pos:to]...)
+			} else {
+				end = to
+			}
+		}
+		field := func() ByteSlice {
+			if buf != nil {
+				return NewByteSlice(buf)
+			}
+			return NewByteSlice(r.s[start:end])
+		}
+
+		for {
+			if i := strings.IndexByte(r.s[pos:r.end], '"'); i >= 0 {
+				q := pos + i
+				appendRaw(q)
+				pos = q + 1
+				switch {
+				case pos < r.end && r.s[pos] == '"':
+					// Escaped quote.
+					if buf == nil {
+						buf = append(make([]byte, 0, q-start+16), r.s[start:q]...)
+					}
+					buf = append(buf, '"')
+					pos++
+				case strings.HasPrefix(r.s[pos:r.end], comma):
+					dst = append(dst, field())
+					pos += len(comma)
+					continue parseField
+				case pos == r.end:
+					dst = append(dst, field())
+					return dst, nil
+				case r.LazyQuotes:
+					// Bare quote.
+					if buf != nil {
+						buf = append(buf, '"')
+					} else {
+						end = pos
+					}
+				default:
+					return dst, &csv.ParseError{StartLine: recLine, Line: r.line, Column: q - r.lineStart + 1, Err: csv.ErrQuote}
+				}
+				continue
+			}
+
+			// The field continues on the next line.
+			switch {
+			case pos == r.end && !r.newline:
+			case buf != nil:
+				buf = append(buf, r.s[pos:r.end]...)
+				if r.newline {
+					buf = append(buf, '\n')
+				}
+			case r.normalized():
+				end = r.next
+			default:
+				buf = append(make([]byte, 0, r.end-start+16), r.s[start:r.end]...)
+				if r.newline {
+					buf = append(buf, '\n')
+				}
+			}
+
+			// A line of a single \r before EOF is empty like EOF.
+			if r.next >= len(r.s) || r.next == len(r.s)-1 && r.s[r.next] == '\r' {
+				if !r.LazyQuotes {
+					column := r.end - r.lineStart + 1
+					if r.newline {
+						column++
+					}
+					return dst, &csv.ParseError{StartLine: recLine, Line: r.line, Column: column, Err: csv.ErrQuote}
+				}
+				dst = append(dst, field())
+				return dst, nil
+			}
+			r.startLine(r.next)
+			pos = r.lineStart
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package readonly_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleCSVReader() {
+	r := readonly.NewCSVReader("name,quote\nalice,\"say \"\"hi\"\"\"\nbob,plain\n")
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		fmt.Println(record.Len(), record.Get(0), record.Get(1))
+	}
+	// Output:
+	// 2 name quote
+	// 2 alice say "hi"
+	// 2 bob plain
+}
+
+func ExampleLoadCSVTable() {
+	table, err := readonly.LoadCSVTable(readonly.NewCSVReader("name,age\ncarol,35\nalice,30\n"))
+	if err != nil {
+		panic(err)
+	}
+	_, _ = table.SortedBy("name").JSON().WriteTo(os.Stdout)
+	// Output:
+	// [{"name":"alice","age":"30"},{"name":"carol","age":"35"}]
+}
+
+var csvInputs = []string{
+	"",
+	"a,b,c\n",
+	"a,b,c",
+	"a,b\r\nc,d\r\n",
+	"a,b\rc\n",
+	"\n\na,b\n\n",
+	"a,b\r",
+	"\r",
+	"a,\n",
+	",,\n",
+	`"a","b""c",""` + "\n",
+	`"a` + "\n" + `b","c"`,
+	`"a` + "\r\n" + `b",c` + "\r\n",
+	`"a,b",c`,
+	`a"b,c`,
+	`"a"b,c`,
+	`"a""`,
+	`"abc`,
+	`"abc` + "\n",
+	`"a` + "\n\r",
+	`"a` + "\n\r\n",
+	`a,"b` + "\n" + `c"d,e` + "\n",
+	"#comment\na,b\n",
+	"a,b\nc\n",
+	"a;b;\"c;d\"\n",
+	"é,\"é\"\n",
+	" a , b \n",
+	"a,\"\"\"\"\n\"\n\"\n",
+}
+
+func TestCSVReader(t *testing.T) {
+	for _, input := range csvInputs {
+		for _, opts := range []struct {
+			comma, comment rune
+			fields         int
+			lazy           bool
+		}{
+			{comma: ','},
+			{comma: ',', lazy: true},
+			{comma: ',', comment: '#', fields: -1},
+			{comma: ';', fields: 2},
+			{comma: 'é', comment: 'b'},
+		} {
+			expected := csv.NewReader(strings.NewReader(input))
+			expected.Comma, expected.Comment, expected.FieldsPerRecord, expected.LazyQuotes = opts.comma, opts.comment, opts.fields, opts.lazy
+			actual := readonly.NewCSVReader(input)
+			actual.Comma, actual.Comment, actual.FieldsPerRecord, actual.LazyQuotes = opts.comma, opts.comment, opts.fields, opts.lazy
+
+			compareCSVReaders(t, fmt.Sprintf("%q %+v", input, opts), expected, actual)
+		}
+	}
+
+	for _, r := range []*readonly.CSVReader{
+		{Comma: ',', Comment: ','},
+		{Comma: '"'},
+		{Comma: ',', Comment: '\n'},
+	} {
+		if _, err := r.Read(); err == nil || errors.Is(err, io.EOF) {
+			t.Fatalf("expected invalid delimiter error, got %v", err)
+		}
+	}
+}
+
+func FuzzCSVReader(f *testing.F) {
+	for _, input := range csvInputs {
+		f.Add(input, false)
+	}
+	f.Fuzz(func(t *testing.T, input string, lazy bool) {
+		expected := csv.NewReader(strings.NewReader(input))
+		expected.LazyQuotes = lazy
+		actual := readonly.NewCSVReader(input)
+		actual.LazyQuotes = lazy
+
+		compareCSVReaders(t, fmt.Sprintf("%q", input), expected, actual)
+	})
+}
+
+func compareCSVReaders(t *testing.T, name string, expected *csv.Reader, actual *readonly.CSVReader) {
+	t.Helper()
+	for i := 0; ; i++ {
+		record, err := expected.Read()
+		actualRecord, actualErr := actual.Read()
+
+		fields := make([]string, 0, actualRecord.Len())
+		actualRecord.Range(func(_ int, field readonly.ByteSlice) bool {
+			fields = append(fields, field.String())
+			return true
+		})
+		if !reflect.DeepEqual(err, actualErr) || len(record) != len(fields) || len(record) > 0 && !reflect.DeepEqual(record, fields) {
+			t.Fatalf("%s: record %d: expected %q %v, got %q %v", name, i, record, err, fields, actualErr)
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) || parseErr.Err != csv.ErrFieldCount {
+				return
+			}
+		}
+	}
+}
+
+func TestCSVReader_ZeroCopy(t *testing.T) {
+	input := readonly.NewByteSlice("plain,\"quoted\",\"multi\nline\",\"esc\"\"aped\",\"crlf\r\n\"\n")
+	record, err := readonly.NewCSVReader(input).Read()
+	if err != nil || record.Len() != 5 {
+		t.Fatalf("unexpected record %v %v", record, err)
+	}
+
+	base := dataPointer(input)
+	for i, offset := range []int{0, 7, 16, -1, -1} {
+		field := record.Get(i)
+		shared := dataPointer(field) == base+uintptr(offset)
+		if offset >= 0 != shared {
+			t.Fatalf("field %d %q: expected to share memory: %v", i, field, offset >= 0)
+		}
+	}
+	if record.Get(4).String() != "crlf\n" {
+		t.Fatalf("expected the normalized line break, got %q", record.Get(4))
+	}
+}
+
+func TestLoadCSVTable(t *testing.T) {
+	for _, input := range []string{"a,b\n1,2\n3\n", "a,b\n1,\"2\n"} {
+		if _, err := readonly.LoadCSVTable(readonly.NewCSVReader(input)); err == nil {
+			t.Fatalf("%q: expected error", input)
+		}
+	}
+
+	table, err := readonly.LoadCSVTable(readonly.NewCSVReader(""))
+	if err != nil || table.Len() != 0 {
+		t.Fatalf("unexpected empty table %v %v", table, err)
+	}
+
+	table, err = readonly.LoadCSVTable(readonly.NewCSVReader("a,b\n1,2\n3,4\n"))
+	b, _ := readonly.ColumnOf[readonly.ByteSlice](table, "b")
+	if err != nil || table.Len() != 2 || b.Get(1).String() != "4" {
+		t.Fatalf("unexpected table %v %v", table, err)
+	}
+}
+
+func BenchmarkCSVReader(b *testing.B) {
+	var sb strings.Builder
+	for i := 0; i < limit/10; i++ {
+		fmt.Fprintf(&sb, "%d,name %d,\"quoted, %d\",%d.5\n", i, i, i, i)
+	}
+	input := sb.String()
+
+	b.Run("readonly", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r := readonly.NewCSVReader(input)
+			for _, err := r.Read(); err == nil; _, err = r.Read() {
+			}
+		}
+	})
+	b.Run("encoding/csv", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r := csv.NewReader(strings.NewReader(input))
+			r.ReuseRecord = true
+			for _, err := r.Read(); err == nil; _, err = r.Read() {
+			}
+		}
+	})
+}
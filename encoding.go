@@ -0,0 +1,205 @@
+package readonly
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+)
+
+// AppendBase64 appends the base64 encoding of b to dst and returns the
+// extended slice. A nil enc means base64.StdEncoding.
+func (b ByteSlice) AppendBase64(dst []byte, enc *base64.Encoding) []byte {
+	if enc == nil {
+		enc = base64.StdEncoding
+	}
+	dst, tail := grow(dst, enc.EncodedLen(len(b.s)))
+	enc.Encode(tail, b.s)
+	return dst
+}
+
+// AppendBase32 appends the base32 encoding of b to dst and returns the
+// extended slice. A nil enc means base32.StdEncoding.
+func (b ByteSlice) AppendBase32(dst []byte, enc *base32.Encoding) []byte {
+	if enc == nil {
+		enc = base32.StdEncoding
+	}
+	dst, tail := grow(dst, enc.EncodedLen(len(b.s)))
+	enc.Encode(tail, b.s)
+	return dst
+}
+
+// AppendHex appends the lower case hex encoding of b to dst and returns
+// the extended slice.
+func (b ByteSlice) AppendHex(dst []byte) []byte {
+	dst, tail := grow(dst, hex.EncodedLen(len(b.s)))
+	hex.Encode(tail, b.s)
+	return dst
+}
+
+// grow extends dst by n bytes and returns it with the new bytes.
+func grow(dst []byte, n int) (extended, tail []byte) {
+	if cap(dst)-len(dst) < n {
+		dst = append(make([]byte, 0, len(dst)+n), dst...)
+	}
+	return dst[:len(dst)+n], dst[len(dst) : len(dst)+n]
+}
+
+// Base64Reader returns a reader of the base64 encoding of b.
+// A nil enc means base64.StdEncoding.
+func (b ByteSlice) Base64Reader(enc *base64.Encoding) *EncodeReader {
+	if enc == nil {
+		enc = base64.StdEncoding
+	}
+	return &EncodeReader{src: b.s, enc: enc, block: 3}
+}
+
+// Base32Reader returns a reader of the base32 encoding of b.
+// A nil enc means base32.StdEncoding.
+func (b ByteSlice) Base32Reader(enc *base32.Encoding) *EncodeReader {
+	if enc == nil {
+		enc = base32.StdEncoding
+	}
+	return &EncodeReader{src: b.s, enc: enc, block: 5}
+}
+
+// HexReader returns a reader of the lower case hex encoding of b.
+func (b ByteSlice) HexReader() *EncodeReader {
+	return &EncodeReader{src: b.s, enc: hexEncoding{}, block: 1}
+}
+
+// encoder is implemented by *base64.Encoding, *base32.Encoding and
+// hexEncoding.
+type encoder interface {
+	Encode(dst, src []byte)
+	EncodedLen(n int) int
+}
+
+type hexEncoding struct{}
+
+func (hexEncoding) Encode(dst, src []byte) { hex.Encode(dst, src) }
+func (hexEncoding) EncodedLen(n int) int   { return hex.EncodedLen(n) }
+
+// encodeChunk is the number of source bytes encoded at once by
+// EncodeReader.WriteTo, a multiple of every block size.
+const encodeChunk = 15 << 10
+
+// EncodeReader implements the io.Reader and io.WriterTo interfaces by
+// encoding a ByteSlice on the fly, see ByteSlice.Base64Reader,
+// ByteSlice.Base32Reader and ByteSlice.HexReader.
+type EncodeReader struct {
+	src     []byte
+	enc     encoder
+	block   int     // number of source bytes encoded independently.
+	pending []byte  // encoded, but not yet read bytes of buf.
+	buf     [8]byte // the largest encoded block.
+}
+
+// Read implements the io.Reader interface.
+func (r *EncodeReader) Read(p []byte) (n int, err error) {
+	if len(r.pending) > 0 {
+		n = copy(p, r.pending)
+		r.pending = r.pending[n:]
+		return n, nil
+	}
+	if len(r.src) == 0 {
+		return 0, io.EOF
+	}
+
+	// Encode as many whole blocks as fit into p directly, or a single
+	// block into the buffer if p is too small.
+	k := len(p) / r.enc.EncodedLen(r.block) * r.block
+	if k == 0 {
+		k = r.block
+		if k > len(r.src) {
+			k = len(r.src)
+		}
+		m := r.enc.EncodedLen(k)
+		r.enc.Encode(r.buf[:m], r.src[:k])
+		r.src = r.src[k:]
+		n = copy(p, r.buf[:m])
+		r.pending = r.buf[n:m]
+		return n, nil
+	}
+
+	if k > len(r.src) {
+		k = len(r.src)
+	}
+	n = r.enc.EncodedLen(k)
+	r.enc.Encode(p[:n], r.src[:k])
+	r.src = r.src[k:]
+	return n, nil
+}
+
+// WriteTo implements the io.WriterTo interface.
+func (r *EncodeReader) WriteTo(w io.Writer) (n int64, err error) {
+	if len(r.pending) > 0 {
+		m, err := w.Write(r.pending)
+		r.pending = r.pending[m:]
+		if n = int64(m); err == nil && len(r.pending) > 0 {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+	if len(r.src) == 0 {
+		return n, nil
+	}
+
+	chunk := encodeChunk
+	if chunk > len(r.src) {
+		chunk = len(r.src)
+	}
+	buf := make([]byte, r.enc.EncodedLen(chunk))
+	for len(r.src) > 0 {
+		k := chunk
+		if k > len(r.src) {
+			k = len(r.src)
+		}
+		encoded := buf[:r.enc.EncodedLen(k)]
+		r.enc.Encode(encoded, r.src[:k])
+		r.src = r.src[k:]
+
+		m, err := w.Write(encoded)
+		n += int64(m)
+		if err == nil && m != len(encoded) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			r.pending = append([]byte(nil), encoded[m:]...)
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// DecodeBase64ToByteSlice returns the decoded src as a read-only
+// ByteSlice. A nil enc means base64.StdEncoding.
+func DecodeBase64ToByteSlice[T ~string | ~[]byte](enc *base64.Encoding, src T) (ByteSlice, error) {
+	if enc == nil {
+		enc = base64.StdEncoding
+	}
+	buf := make([]byte, enc.DecodedLen(len(src)))
+	n, err := enc.Decode(buf, NewByteSlice(src).s) // Decode doesn't modify src.
+	return NewByteSlice(buf[:n]), err
+}
+
+// DecodeBase32ToByteSlice returns the decoded src as a read-only
+// ByteSlice. A nil enc means base32.StdEncoding.
+func DecodeBase32ToByteSlice[T ~string | ~[]byte](enc *base32.Encoding, src T) (ByteSlice, error) {
+	if enc == nil {
+		enc = base32.StdEncoding
+	}
+	buf := make([]byte, enc.DecodedLen(len(src)))
+	n, err := enc.Decode(buf, NewByteSlice(src).s) // Decode doesn't modify src.
+	return NewByteSlice(buf[:n]), err
+}
+
+// DecodeHexToByteSlice returns the decoded src as a read-only
+// ByteSlice.
+func DecodeHexToByteSlice[T ~string | ~[]byte](src T) (ByteSlice, error) {
+	buf := make([]byte, hex.DecodedLen(len(src)))
+	n, err := hex.Decode(buf, NewByteSlice(src).s) // Decode doesn't modify src.
+	return NewByteSlice(buf[:n]), err
+}
@@ -0,0 +1,218 @@
+package readonly_test
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleByteSlice_AppendBase64() {
+	b := readonly.NewByteSlice("some text")
+
+	fmt.Println(string(b.AppendBase64([]byte("base64: "), nil)))
+	fmt.Println(string(b.AppendBase32([]byte("base32: "), nil)))
+	fmt.Println(string(b.AppendHex([]byte("hex: "))))
+	// Output:
+	// base64: c29tZSB0ZXh0
+	// base32: ONXW2ZJAORSXQ5A=
+	// hex: 736f6d652074657874
+}
+
+func ExampleDecodeBase64ToByteSlice() {
+	b, err := readonly.DecodeBase64ToByteSlice(base64.URLEncoding, "c29tZSB0ZXh0")
+	fmt.Println(b, err)
+	// Output:
+	// some text <nil>
+}
+
+func TestEncodeReader(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 3, 4, 5, 100, 10000} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i * 7)
+		}
+		b := readonly.NewByteSlice(data)
+
+		for _, c := range []struct {
+			name     string
+			r        func() *readonly.EncodeReader
+			expected string
+		}{
+			{"hex", b.HexReader, hex.EncodeToString(data)},
+			{"base64", func() *readonly.EncodeReader { return b.Base64Reader(nil) }, base64.StdEncoding.EncodeToString(data)},
+			{
+				"raw base64",
+				func() *readonly.EncodeReader { return b.Base64Reader(base64.RawURLEncoding) },
+				base64.RawURLEncoding.EncodeToString(data),
+			},
+			{"base32", func() *readonly.EncodeReader { return b.Base32Reader(nil) }, base32.StdEncoding.EncodeToString(data)},
+			{
+				"raw base32",
+				func() *readonly.EncodeReader { return b.Base32Reader(base32.HexEncoding.WithPadding(base32.NoPadding)) },
+				base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString(data),
+			},
+		} {
+			if err := iotest.TestReader(c.r(), []byte(c.expected)); err != nil {
+				t.Fatalf("[%d] %s: %v", size, c.name, err)
+			}
+
+			var buf bytes.Buffer
+			if n, err := c.r().WriteTo(&buf); err != nil || n != int64(len(c.expected)) || buf.String() != c.expected {
+				t.Fatalf("[%d] %s: expected %q, got %d %v %q", size, c.name, c.expected, n, err, buf.String())
+			}
+
+			// Continue writing after partial reads and failed writes.
+			r := c.r()
+			buf.Reset()
+			_, _ = io.CopyN(&buf, iotest.OneByteReader(r), 3)
+			var failing writer = func(p []byte) (int, error) { return len(p) / 2, errors.New("some error") }
+			n, _ := r.WriteTo(failing)
+			buf.WriteString(c.expected[buf.Len() : buf.Len()+int(n)])
+			if _, err := r.WriteTo(&buf); err != nil || buf.String() != c.expected {
+				t.Fatalf("[%d] %s: expected %q, got %v %q", size, c.name, c.expected, err, buf.String())
+			}
+		}
+	}
+}
+
+func TestDecodeHexToByteSlice(t *testing.T) {
+	b, err := readonly.DecodeHexToByteSlice([]byte("736f6d65"))
+	if err != nil || b.String() != "some" {
+		t.Fatalf("expected %q, got %q %v", "some", b, err)
+	}
+	if _, err = readonly.DecodeHexToByteSlice("zz"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if _, err = readonly.DecodeBase64ToByteSlice(nil, "!"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	b, err = readonly.DecodeBase32ToByteSlice(nil, "ONXW2ZI=")
+	if err != nil || b.String() != "some" {
+		t.Fatalf("expected %q, got %q %v", "some", b, err)
+	}
+	if _, err = readonly.DecodeBase32ToByteSlice(nil, "!"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func BenchmarkByteSlice_Base64(b *testing.B) {
+	data := readonly.NewByteSlice(make([]byte, 1<<12))
+	dst := make([]byte, 0, base64.StdEncoding.EncodedLen(data.Len()))
+
+	b.Run("AppendBase64", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dst = data.AppendBase64(dst[:0], nil)
+		}
+	})
+	b.Run("Copy+EncodeToString", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = base64.StdEncoding.EncodeToString(data.Copy())
+		}
+	})
+	b.Run("Base64Reader", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = data.Base64Reader(nil).WriteTo(io.Discard)
+		}
+	})
+	b.Run("base64.NewEncoder", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			e := base64.NewEncoder(base64.StdEncoding, io.Discard)
+			_, _ = data.WriteTo(e)
+			_ = e.Close()
+		}
+	})
+}
+
+func BenchmarkByteSlice_Base32(b *testing.B) {
+	data := readonly.NewByteSlice(make([]byte, 1<<12))
+	dst := make([]byte, 0, base32.StdEncoding.EncodedLen(data.Len()))
+
+	b.Run("AppendBase32", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dst = data.AppendBase32(dst[:0], nil)
+		}
+	})
+	b.Run("Copy+EncodeToString", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = base32.StdEncoding.EncodeToString(data.Copy())
+		}
+	})
+	b.Run("Base32Reader", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = data.Base32Reader(nil).WriteTo(io.Discard)
+		}
+	})
+}
+
+func BenchmarkByteSlice_Hex(b *testing.B) {
+	data := readonly.NewByteSlice(make([]byte, 1<<12))
+	dst := make([]byte, 0, hex.EncodedLen(data.Len()))
+
+	b.Run("AppendHex", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dst = data.AppendHex(dst[:0])
+		}
+	})
+	b.Run("Copy+EncodeToString", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = hex.EncodeToString(data.Copy())
+		}
+	})
+	b.Run("HexReader", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = data.HexReader().WriteTo(io.Discard)
+		}
+	})
+}
+
+func BenchmarkDecodeBase64ToByteSlice(b *testing.B) {
+	src := base64.StdEncoding.EncodeToString(make([]byte, 1<<12))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = readonly.DecodeBase64ToByteSlice(nil, src)
+	}
+}
+
+func BenchmarkDecodeBase32ToByteSlice(b *testing.B) {
+	src := base32.StdEncoding.EncodeToString(make([]byte, 1<<12))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = readonly.DecodeBase32ToByteSlice(nil, src)
+	}
+}
+
+func BenchmarkDecodeHexToByteSlice(b *testing.B) {
+	src := hex.EncodeToString(make([]byte, 1<<12))
+
+	b.Run("DecodeHexToByteSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = readonly.DecodeHexToByteSlice(src)
+		}
+	})
+	b.Run("hex.DecodeString", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = hex.DecodeString(src)
+		}
+	})
+}
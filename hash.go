@@ -0,0 +1,65 @@
+package readonly
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"hash/crc32"
+	"hash/maphash"
+)
+
+// Sum64 returns the maphash of b with the given seed.
+// Equal byte sequences have equal hashes for the same seed.
+func (b ByteSlice) Sum64(seed maphash.Seed) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	_, _ = h.Write(b.s)
+	return h.Sum64()
+}
+
+// CRC32 equivalent to crc32.Checksum(b, table).
+// A nil table means crc32.IEEETable.
+func (b ByteSlice) CRC32(table *crc32.Table) uint32 {
+	if table == nil {
+		return crc32.ChecksumIEEE(b.s)
+	}
+	return crc32.Checksum(b.s, table)
+}
+
+// SHA256 equivalent to sha256.Sum256(b).
+func (b ByteSlice) SHA256() [sha256.Size]byte { return sha256.Sum256(b.s) }
+
+// SHA256Hex returns the SHA256 checksum of b in lower case hex.
+func (b ByteSlice) SHA256Hex() string {
+	sum := sha256.Sum256(b.s)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashWith writes b to h and returns h.Sum(nil).
+// Unlike WriteTo, it relies on hash.Hash never returning an error, so
+// no checks are made. h is not reset before writing.
+// h must not modify the slice data, see io.Writer.
+func (b ByteSlice) HashWith(h hash.Hash) []byte {
+	_, _ = h.Write(b.s)
+	return h.Sum(nil)
+}
+
+// NewContentKey returns a ContentKey of b, computing its digest.
+func NewContentKey(b ByteSlice) ContentKey { return ContentKey{b: b, sum: sha256.Sum256(b.s)} }
+
+// ContentKey is a ByteSlice together with its SHA256 digest, which is
+// computed only once, when the key is created.
+type ContentKey struct {
+	b   ByteSlice
+	sum [sha256.Size]byte
+}
+
+// Bytes returns the content.
+func (k ContentKey) Bytes() ByteSlice { return k.b }
+
+// Digest returns the SHA256 digest of the content, which can be used
+// as a map key.
+func (k ContentKey) Digest() [sha256.Size]byte { return k.sum }
+
+// String returns the digest in lower case hex.
+func (k ContentKey) String() string { return hex.EncodeToString(k.sum[:]) }
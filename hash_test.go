@@ -0,0 +1,77 @@
+package readonly_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"hash/crc32"
+	"hash/maphash"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleByteSlice_SHA256Hex() {
+	fmt.Println(readonly.NewByteSlice("some text").SHA256Hex())
+	// Output:
+	// b94f6f125c79e3a5ffaa826f584c10d52ada669e6762051b826b55776d05aed2
+}
+
+func ExampleNewContentKey() {
+	cache := map[[sha256.Size]byte]string{}
+
+	k := readonly.NewContentKey(readonly.NewByteSlice("some text"))
+	cache[k.Digest()] = k.Bytes().String()
+
+	fmt.Println(cache[readonly.NewContentKey(readonly.NewByteSlice([]byte("some text"))).Digest()])
+	fmt.Println(k)
+	// Output:
+	// some text
+	// b94f6f125c79e3a5ffaa826f584c10d52ada669e6762051b826b55776d05aed2
+}
+
+func TestByteSlice_Hash(t *testing.T) {
+	data := []byte("some text")
+	b := readonly.NewByteSlice(data)
+
+	seed := maphash.MakeSeed()
+	var h maphash.Hash
+	h.SetSeed(seed)
+	_, _ = h.Write(data)
+	if b.Sum64(seed) != h.Sum64() {
+		t.Fatalf("expected %d, got %d", h.Sum64(), b.Sum64(seed))
+	}
+
+	if b.CRC32(nil) != crc32.ChecksumIEEE(data) {
+		t.Fatalf("expected %d, got %d", crc32.ChecksumIEEE(data), b.CRC32(nil))
+	}
+	table := crc32.MakeTable(crc32.Castagnoli)
+	if b.CRC32(table) != crc32.Checksum(data, table) {
+		t.Fatalf("expected %d, got %d", crc32.Checksum(data, table), b.CRC32(table))
+	}
+
+	expected := sha256.Sum256(data)
+	if actual := b.HashWith(sha256.New()); !bytes.Equal(expected[:], actual) || b.SHA256() != expected {
+		t.Fatalf("expected %x, got %x and %x", expected, actual, b.SHA256())
+	}
+}
+
+func BenchmarkByteSlice_HashWith(b *testing.B) {
+	data := readonly.NewByteSlice(make([]byte, 1<<10))
+
+	b.Run("HashWith", func(b *testing.B) {
+		h := crc32.NewIEEE()
+		for i := 0; i < b.N; i++ {
+			h.Reset()
+			data.HashWith(h)
+		}
+	})
+	b.Run("WriteTo", func(b *testing.B) {
+		h := crc32.NewIEEE()
+		for i := 0; i < b.N; i++ {
+			h.Reset()
+			_, _ = data.WriteTo(h)
+			h.Sum(nil)
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package readonly_test
+
+import "testing"
+
+// mustPanic fails the test if f doesn't panic.
+func mustPanic(t *testing.T, f func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	f()
+}
@@ -0,0 +1,152 @@
+package readonly
+
+import (
+	"container/list"
+	"hash/maphash"
+	"sync"
+	"unsafe"
+)
+
+// InternerConfig configures an Interner.
+type InternerConfig struct {
+	// Shards is the number of independently locked parts of the table.
+	// More shards reduce lock contention under concurrent use.
+	// Values less than 1 mean a single shard.
+	Shards int
+
+	// MaxEntries bounds the number of interned strings. When the limit
+	// is reached, the least recently used strings are evicted.
+	// The limit is split evenly between shards.
+	// Values less than 1 mean no limit.
+	MaxEntries int
+}
+
+// InternerStats contains Interner usage statistics.
+type InternerStats struct {
+	Hits, Misses, Evictions uint64
+
+	// Entries is the number of strings currently interned.
+	Entries int
+
+	// BytesSaved is the total length of the hits, i.e. the number of
+	// bytes that would have been stored if the strings were not interned.
+	BytesSaved uint64
+}
+
+// NewInterner returns a new Interner configured by c.
+func NewInterner(c InternerConfig) *Interner {
+	n := c.Shards
+	if n < 1 {
+		n = 1
+	}
+
+	in := &Interner{shards: make([]internerShard, n), seed: maphash.MakeSeed()}
+	if c.MaxEntries > 0 {
+		limit := (c.MaxEntries + n - 1) / n
+		for i := range in.shards {
+			in.shards[i].limit = limit
+			in.shards[i].lru = list.New()
+		}
+	}
+	return in
+}
+
+// Interner is a table of canonical byte sequences, so that equal
+// strings share the same memory.
+// It is safe for concurrent use.
+// The zero value is an unbounded Interner with a single shard.
+type Interner struct {
+	once   sync.Once
+	shards []internerShard
+	seed   maphash.Seed
+}
+
+func (in *Interner) init() {
+	in.once.Do(func() {
+		if in.shards == nil {
+			in.shards = make([]internerShard, 1)
+		}
+	})
+}
+
+type internerShard struct {
+	mu    sync.Mutex
+	m     map[string]*internerEntry
+	lru   *list.List // nil if the shard is unbounded.
+	limit int
+
+	hits, misses, evictions, saved uint64
+}
+
+type internerEntry struct {
+	s    string
+	elem *list.Element
+}
+
+// Intern returns the canonical ByteSlice equal to src.
+// The result is backed by memory owned by the Interner, so it stays
+// valid and unchanged even if src is modified or the entry is evicted.
+// src is copied only the first time it is seen.
+func Intern[T ~string | ~[]byte | ByteSlice](in *Interner, src T) ByteSlice {
+	return NewByteSlice(in.intern(*(*string)(unsafe.Pointer(&src))))
+}
+
+// String is like Intern, but returns a string.
+func (in *Interner) String(s string) string { return in.intern(s) }
+
+// intern must not retain s, it may point to mutable memory.
+func (in *Interner) intern(s string) string {
+	in.init()
+	sh := &in.shards[0]
+	if len(in.shards) > 1 {
+		var h maphash.Hash
+		h.SetSeed(in.seed)
+		_, _ = h.WriteString(s)
+		sh = &in.shards[h.Sum64()%uint64(len(in.shards))]
+	}
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	if e, ok := sh.m[s]; ok {
+		sh.hits++
+		sh.saved += uint64(len(s))
+		if sh.lru != nil {
+			sh.lru.MoveToFront(e.elem)
+		}
+		return e.s
+	}
+
+	sh.misses++
+	if sh.m == nil {
+		sh.m = make(map[string]*internerEntry)
+	}
+	e := &internerEntry{s: string([]byte(s))}
+	sh.m[e.s] = e
+	if sh.lru != nil {
+		e.elem = sh.lru.PushFront(e)
+		for sh.lru.Len() > sh.limit {
+			//nolint:forcetypeassert
+			old := sh.lru.Remove(sh.lru.Back()).(*internerEntry)
+			delete(sh.m, old.s)
+			sh.evictions++
+		}
+	}
+	return e.s
+}
+
+// Stats returns the usage statistics summed over all shards.
+func (in *Interner) Stats() (s InternerStats) {
+	in.init()
+	for i := range in.shards {
+		sh := &in.shards[i]
+		sh.mu.Lock()
+		s.Hits += sh.hits
+		s.Misses += sh.misses
+		s.Evictions += sh.evictions
+		s.BytesSaved += sh.saved
+		s.Entries += len(sh.m)
+		sh.mu.Unlock()
+	}
+	return s
+}
@@ -0,0 +1,130 @@
+package readonly_test
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleIntern() {
+	in := readonly.NewInterner(readonly.InternerConfig{})
+
+	buf := []byte("GET")
+	a := readonly.Intern(in, buf)
+	buf[0] = 'S' // doesn't affect the interned value.
+	b := readonly.Intern(in, "GET")
+	c := readonly.Intern(in, readonly.NewByteSlice("GET"))
+
+	fmt.Println(a, b, c)
+	fmt.Printf("%+v\n", in.Stats())
+	// Output:
+	// GET GET GET
+	// {Hits:2 Misses:1 Evictions:0 Entries:1 BytesSaved:6}
+}
+
+func TestIntern_Shared(t *testing.T) {
+	in := readonly.NewInterner(readonly.InternerConfig{Shards: 4})
+
+	a := readonly.Intern(in, []byte("some string"))
+	b := readonly.Intern(in, []byte("some string"))
+	c := readonly.NewByteSlice(in.String("some string"))
+	if dataPointer(a) != dataPointer(b) || dataPointer(a) != dataPointer(c) {
+		t.Fatalf("expected interned values to share memory")
+	}
+}
+
+func TestInterner_ZeroValue(t *testing.T) {
+	var in readonly.Interner
+	if stats := in.Stats(); stats.Entries != 0 {
+		t.Fatalf("expected an empty interner, got %+v", stats)
+	}
+
+	a := readonly.Intern(&in, "some string")
+	b := readonly.Intern(&in, []byte("some string"))
+	if dataPointer(a) != dataPointer(b) || in.Stats().Hits != 1 {
+		t.Fatalf("expected interned values to share memory, got %+v", in.Stats())
+	}
+}
+
+func dataPointer(b readonly.ByteSlice) uintptr {
+	s := b.String()
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func TestInterner_Bounded(t *testing.T) {
+	in := readonly.NewInterner(readonly.InternerConfig{MaxEntries: 2})
+
+	first := readonly.Intern(in, "a")
+	readonly.Intern(in, "b")
+	readonly.Intern(in, "a") // "a" becomes the most recently used.
+	readonly.Intern(in, "c") // evicts "b".
+	readonly.Intern(in, "a")
+
+	stats := in.Stats()
+	if stats.Entries != 2 || stats.Evictions != 1 || stats.Hits != 2 || stats.Misses != 3 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if first.String() != "a" {
+		t.Fatalf("expected %q, got %q", "a", first)
+	}
+
+	readonly.Intern(in, "b")
+	if stats = in.Stats(); stats.Misses != 4 || stats.Evictions != 2 {
+		t.Fatalf("expected evicted value to be interned again, got %+v", stats)
+	}
+}
+
+// Run with -race.
+func TestInterner_Concurrent(t *testing.T) {
+	const goroutines, values = 8, 1000
+
+	in := readonly.NewInterner(readonly.InternerConfig{Shards: 4, MaxEntries: values / 2})
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, 0, 8)
+			for i := 0; i < values; i++ {
+				buf = strconv.AppendInt(buf[:0], int64(i), 10)
+				if s := readonly.Intern(in, buf); s.String() != string(buf) {
+					t.Errorf("expected %q, got %q", buf, s)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := in.Stats()
+	if stats.Hits+stats.Misses != goroutines*values || stats.Entries > values/2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func BenchmarkIntern(b *testing.B) {
+	keys := make([][]byte, 1024)
+	for i := range keys {
+		keys[i] = []byte("key-" + strconv.Itoa(i))
+	}
+
+	for _, shards := range []int{1, 8} {
+		b.Run(strconv.Itoa(shards)+" shards", func(b *testing.B) {
+			in := readonly.NewInterner(readonly.InternerConfig{Shards: shards})
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					readonly.Intern(in, keys[i%len(keys)])
+				}
+			})
+		})
+	}
+}
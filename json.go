@@ -0,0 +1,547 @@
+package readonly
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// ErrJSONPathNotFound is returned by JSONTokenizer.Path if there is no
+// value at the path.
+var ErrJSONPathNotFound = errors.New("readonly: JSON path not found")
+
+var errJSONPath = errors.New("readonly.JSONTokenizer.Path: invalid path")
+
+// JSONSyntaxError is a description of a JSON syntax error.
+type JSONSyntaxError struct {
+	Offset int // the error occurred after reading Offset bytes.
+	msg    string
+}
+
+func (e *JSONSyntaxError) Error() string {
+	return "readonly: JSON syntax error at offset " + strconv.Itoa(e.Offset) + ": " + e.msg
+}
+
+// JSONKind is a kind of JSONToken.
+type JSONKind uint8
+
+// Kinds of JSON tokens.
+const (
+	JSONBeginObject JSONKind = iota + 1
+	JSONEndObject
+	JSONBeginArray
+	JSONEndArray
+	JSONKey
+	JSONString
+	JSONNumber
+	JSONBool
+	JSONNull
+)
+
+var jsonKinds = [...]string{
+	JSONBeginObject: "BeginObject",
+	JSONEndObject:   "EndObject",
+	JSONBeginArray:  "BeginArray",
+	JSONEndArray:    "EndArray",
+	JSONKey:         "Key",
+	JSONString:      "String",
+	JSONNumber:      "Number",
+	JSONBool:        "Bool",
+	JSONNull:        "Null",
+}
+
+func (k JSONKind) String() string {
+	if int(k) < len(jsonKinds) && jsonKinds[k] != "" {
+		return jsonKinds[k]
+	}
+	return "JSONKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// JSONToken is a token of JSON input. Raw is a view of the input,
+// including the quotes of keys and strings.
+type JSONToken struct {
+	Kind JSONKind
+	Raw  ByteSlice
+
+	escaped bool // the string has escapes or invalid UTF-8.
+}
+
+// Value returns the contents of a key or a string without the quotes
+// and unescaping, and Raw for other kinds.
+func (t JSONToken) Value() ByteSlice {
+	if t.Kind == JSONKey || t.Kind == JSONString {
+		return ByteSlice{Slice[byte]{t.Raw.s[1 : len(t.Raw.s)-1 : len(t.Raw.s)-1]}}
+	}
+	return t.Raw
+}
+
+// Unquote returns the unescaped contents of a key or a string, like
+// encoding/json decodes them, and Raw for other kinds. The result is
+// copied only if the string has escapes or invalid UTF-8.
+func (t JSONToken) Unquote() ByteSlice {
+	v := t.Value()
+	if !t.escaped || t.Kind != JSONKey && t.Kind != JSONString {
+		return v
+	}
+	return ByteSlice{Slice[byte]{unquoteJSON(v.String())}}
+}
+
+// Bool returns true if the token is the literal true.
+func (t JSONToken) Bool() bool { return t.Kind == JSONBool && t.Raw.String() == "true" }
+
+// NewJSONTokenizer returns a new JSONTokenizer reading from src.
+func NewJSONTokenizer[T ~string | ~[]byte | ByteSlice](src T) *JSONTokenizer {
+	return &JSONTokenizer{s: *(*string)(unsafe.Pointer(&src))}
+}
+
+// JSONTokenizer splits JSON input into tokens without copying it.
+// The input can be a stream of JSON values like for json.Decoder.
+type JSONTokenizer struct {
+	s     string
+	pos   int
+	start int    // offset of the last token.
+	stack []byte // kinds of the open containers, '{' or '['.
+	state jsonState
+}
+
+type jsonState uint8
+
+const (
+	jsonValue jsonState = iota
+	jsonValueOrEnd
+	jsonKey
+	jsonKeyOrEnd
+	jsonColon
+	jsonCommaOrEnd
+)
+
+// Offset returns the number of read bytes.
+func (t *JSONTokenizer) Offset() int { return t.pos }
+
+// Depth returns the number of open objects and arrays.
+func (t *JSONTokenizer) Depth() int { return len(t.stack) }
+
+// Next returns the next token. Commas and colons are checked but not
+// returned. At the end of the input between top-level values, Next
+// returns io.EOF.
+func (t *JSONTokenizer) Next() (JSONToken, error) {
+	for {
+		for t.pos < len(t.s) && isJSONSpace(t.s[t.pos]) {
+			t.pos++
+		}
+		if t.pos == len(t.s) {
+			if t.state == jsonValue && len(t.stack) == 0 {
+				return JSONToken{}, io.EOF
+			}
+			return JSONToken{}, t.errorf("unexpected end of JSON input")
+		}
+
+		t.start = t.pos
+		c := t.s[t.pos]
+		switch t.state {
+		case jsonColon:
+			if c != ':' {
+				return JSONToken{}, t.errorf("invalid character %q after object key", c)
+			}
+			t.pos++
+			t.state = jsonValue
+			continue
+
+		case jsonCommaOrEnd:
+			switch {
+			case c == ',':
+				t.pos++
+				t.state = jsonValue
+				if t.stack[len(t.stack)-1] == '{' {
+					t.state = jsonKey
+				}
+				continue
+			case c == '}' && t.stack[len(t.stack)-1] == '{':
+				return t.end(JSONEndObject), nil
+			case c == ']' && t.stack[len(t.stack)-1] == '[':
+				return t.end(JSONEndArray), nil
+			}
+			return JSONToken{}, t.errorf("invalid character %q after value", c)
+
+		case jsonKeyOrEnd, jsonKey:
+			if c == '}' && t.state == jsonKeyOrEnd {
+				return t.end(JSONEndObject), nil
+			}
+			if c != '"' {
+				return JSONToken{}, t.errorf("invalid character %q looking for object key", c)
+			}
+			tok, err := t.string(JSONKey)
+			t.state = jsonColon
+			return tok, err
+
+		case jsonValueOrEnd:
+			if c == ']' {
+				return t.end(JSONEndArray), nil
+			}
+		}
+		return t.value(c)
+	}
+}
+
+// Skip skips the next value, the rest of the current object or array
+// if the next token is its end.
+func (t *JSONTokenizer) Skip() error {
+	tok, err := t.Next()
+	if err != nil {
+		return err
+	}
+	return t.skip(tok)
+}
+
+// skip skips the rest of the value starting with tok.
+func (t *JSONTokenizer) skip(tok JSONToken) error {
+	if tok.Kind != JSONBeginObject && tok.Kind != JSONBeginArray {
+		return nil
+	}
+	for depth := len(t.stack); len(t.stack) >= depth; {
+		if _, err := t.Next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Path reads the next value until the value at query within it and
+// returns its raw JSON, so the rest of the input is not parsed.
+// After Path, Next continues with the tokens after the found value.
+//
+// query is a sequence of object keys separated by dots and array
+// indexes in brackets, e.g. "a.b[2].c". A backslash escapes the next
+// character of a key, e.g. `a\.b` is the key "a.b". An empty query is
+// the whole value. If there is no such value, Path returns
+// ErrJSONPathNotFound.
+func (t *JSONTokenizer) Path(query string) (ByteSlice, error) {
+	path, err := parseJSONPath(query)
+	if err != nil {
+		return ByteSlice{}, err
+	}
+	tok, err := t.Next()
+	if err != nil {
+		return ByteSlice{}, err
+	}
+	for _, elem := range path {
+		if tok, err = t.pathElem(tok, elem.key, elem.index); err != nil {
+			return ByteSlice{}, err
+		}
+	}
+
+	start := t.start
+	if err = t.skip(tok); err != nil {
+		return ByteSlice{}, err
+	}
+	if tok.Kind != JSONBeginObject && tok.Kind != JSONBeginArray {
+		return tok.Raw, nil
+	}
+	return NewByteSlice(t.s[start:t.pos]), nil
+}
+
+// jsonPathElem is an object key or an array index if index >= 0.
+type jsonPathElem struct {
+	key   string
+	index int
+}
+
+func parseJSONPath(query string) (path []jsonPathElem, err error) {
+	for rest := query; rest != ""; {
+		elem := jsonPathElem{index: -1}
+		if rest[0] == '[' {
+			i := strings.IndexByte(rest, ']')
+			if i < 0 {
+				return nil, errJSONPath
+			}
+			if elem.index, err = strconv.Atoi(rest[1:i]); err != nil || elem.index < 0 {
+				return nil, errJSONPath
+			}
+			if rest = rest[i+1:]; rest != "" && rest[0] != '.' && rest[0] != '[' {
+				return nil, errJSONPath
+			}
+		} else {
+			i := 0
+			for ; i < len(rest) && rest[i] != '.' && rest[i] != '['; i++ {
+				if rest[i] == '\\' {
+					if i++; i == len(rest) {
+						return nil, errJSONPath
+					}
+				}
+			}
+			if i == 0 {
+				return nil, errJSONPath
+			}
+			elem.key, rest = unescapeJSONPathKey(rest[:i]), rest[i:]
+		}
+		if strings.HasPrefix(rest, ".") {
+			if rest = rest[1:]; rest == "" || rest[0] == '[' {
+				return nil, errJSONPath
+			}
+		}
+		path = append(path, elem)
+	}
+	return path, nil
+}
+
+// unescapeJSONPathKey removes the backslashes escaping the characters
+// of key.
+func unescapeJSONPathKey(key string) string {
+	if strings.IndexByte(key, '\\') < 0 {
+		return key
+	}
+	b := make([]byte, 0, len(key))
+	for i := 0; i < len(key); i++ {
+		if key[i] == '\\' {
+			i++
+		}
+		b = append(b, key[i])
+	}
+	return string(b)
+}
+
+// pathElem returns the first token of the value with the key or index
+// within the value starting with tok.
+func (t *JSONTokenizer) pathElem(tok JSONToken, key string, index int) (JSONToken, error) {
+	var err error
+	if index < 0 && tok.Kind != JSONBeginObject || index >= 0 && tok.Kind != JSONBeginArray {
+		return JSONToken{}, ErrJSONPathNotFound
+	}
+	for i := 0; ; i++ {
+		if tok, err = t.Next(); err != nil {
+			return JSONToken{}, err
+		}
+		if tok.Kind == JSONEndObject || tok.Kind == JSONEndArray {
+			return JSONToken{}, ErrJSONPathNotFound
+		}
+		if tok.Kind == JSONKey {
+			found := tok.Unquote().String() == key
+			if tok, err = t.Next(); err != nil || found {
+				return tok, err
+			}
+		} else if i == index {
+			return tok, nil
+		}
+		if err = t.skip(tok); err != nil {
+			return JSONToken{}, err
+		}
+	}
+}
+
+func (t *JSONTokenizer) value(c byte) (JSONToken, error) {
+	switch {
+	case c == '{' || c == '[':
+		t.stack = append(t.stack, c)
+		t.pos++
+		if c == '{' {
+			t.state = jsonKeyOrEnd
+			return t.token(JSONBeginObject, false), nil
+		}
+		t.state = jsonValueOrEnd
+		return t.token(JSONBeginArray, false), nil
+	case c == '"':
+		tok, err := t.string(JSONString)
+		t.afterValue()
+		return tok, err
+	case c == '-' || '0' <= c && c <= '9':
+		if err := t.number(); err != nil {
+			return JSONToken{}, err
+		}
+		t.afterValue()
+		return t.token(JSONNumber, false), nil
+	}
+
+	for _, literal := range [...]string{"true", "false", "null"} {
+		if strings.HasPrefix(t.s[t.pos:], literal) {
+			t.pos += len(literal)
+			t.afterValue()
+			if literal == "null" {
+				return t.token(JSONNull, false), nil
+			}
+			return t.token(JSONBool, false), nil
+		}
+	}
+	return JSONToken{}, t.errorf("invalid character %q looking for beginning of value", c)
+}
+
+func (t *JSONTokenizer) end(kind JSONKind) JSONToken {
+	t.pos++
+	t.stack = t.stack[:len(t.stack)-1]
+	t.afterValue()
+	return t.token(kind, false)
+}
+
+func (t *JSONTokenizer) afterValue() {
+	t.state = jsonValue
+	if len(t.stack) > 0 {
+		t.state = jsonCommaOrEnd
+	}
+}
+
+func (t *JSONTokenizer) token(kind JSONKind, escaped bool) JSONToken {
+	return JSONToken{Kind: kind, Raw: NewByteSlice(t.s[t.start:t.pos]), escaped: escaped}
+}
+
+func (t *JSONTokenizer) string(kind JSONKind) (JSONToken, error) {
+	var escaped bool
+	for i := t.pos + 1; i < len(t.s); {
+		switch c := t.s[i]; {
+		case c == '"':
+			t.pos = i + 1
+			return t.token(kind, escaped), nil
+		case c == '\\':
+			escaped = true
+			if i+1 == len(t.s) {
+				i++
+				continue
+			}
+			switch t.s[i+1] {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+				i += 2
+			case 'u':
+				if i+6 > len(t.s) || !isHex(t.s[i+2:i+6]) {
+					t.pos = i + 1
+					return JSONToken{}, t.errorf("invalid character in \\u hexadecimal character escape")
+				}
+				i += 6
+			default:
+				t.pos = i + 1
+				return JSONToken{}, t.errorf("invalid character %q in string escape code", t.s[i+1])
+			}
+		case c < ' ':
+			t.pos = i
+			return JSONToken{}, t.errorf("invalid character %q in string literal", c)
+		case c < utf8.RuneSelf:
+			i++
+		default:
+			r, size := utf8.DecodeRuneInString(t.s[i:])
+			escaped = escaped || r == utf8.RuneError && size == 1
+			i += size
+		}
+	}
+	t.pos = len(t.s)
+	return JSONToken{}, t.errorf("unexpected end of JSON input")
+}
+
+func (t *JSONTokenizer) number() error {
+	i := t.pos
+	if t.s[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(t.s) && t.s[i] == '0':
+		i++
+	case i < len(t.s) && '1' <= t.s[i] && t.s[i] <= '9':
+		i = skipDigits(t.s, i)
+	default:
+		t.pos = i
+		return t.errorf("invalid number")
+	}
+	if i < len(t.s) && t.s[i] == '.' {
+		if j := skipDigits(t.s, i+1); j > i+1 {
+			i = j
+		} else {
+			t.pos = i + 1
+			return t.errorf("invalid number")
+		}
+	}
+	if i < len(t.s) && (t.s[i] == 'e' || t.s[i] == 'E') {
+		i++
+		if i < len(t.s) && (t.s[i] == '+' || t.s[i] == '-') {
+			i++
+		}
+		if j := skipDigits(t.s, i); j > i {
+			i = j
+		} else {
+			t.pos = i
+			return t.errorf("invalid number")
+		}
+	}
+	t.pos = i
+	return nil
+}
+
+func (t *JSONTokenizer) errorf(format string, args ...any) error {
+	return &JSONSyntaxError{Offset: t.pos, msg: fmt.Sprintf(format, args...)}
+}
+
+func skipDigits(s string, i int) int {
+	for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+func isJSONSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' }
+
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// unquoteJSON unescapes the validated contents of a JSON string like
+// encoding/json: invalid UTF-8 and surrogates are replaced with
+// utf8.RuneError.
+func unquoteJSON(s string) []byte {
+	b := make([]byte, 0, len(s)+2*utf8.UTFMax)
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\':
+			switch c = s[i+1]; c {
+			case 'b':
+				b = append(b, '\b')
+			case 'f':
+				b = append(b, '\f')
+			case 'n':
+				b = append(b, '\n')
+			case 'r':
+				b = append(b, '\r')
+			case 't':
+				b = append(b, '\t')
+			case 'u':
+				r := hexRune(s[i+2 : i+6])
+				i += 6
+				if utf16.IsSurrogate(r) {
+					if i+6 <= len(s) && s[i] == '\\' && s[i+1] == 'u' {
+						if dec := utf16.DecodeRune(r, hexRune(s[i+2:i+6])); dec != utf8.RuneError {
+							r = dec
+							i += 6
+						} else {
+							r = utf8.RuneError
+						}
+					} else {
+						r = utf8.RuneError
+					}
+				}
+				b = utf8.AppendRune(b, r)
+				continue
+			default: // '"', '\\', '/'
+				b = append(b, c)
+			}
+			i += 2
+		case c < utf8.RuneSelf:
+			b = append(b, c)
+			i++
+		default:
+			r, size := utf8.DecodeRuneInString(s[i:])
+			b = utf8.AppendRune(b, r)
+			i += size
+		}
+	}
+	return b
+}
+
+func hexRune(s string) rune {
+	r, _ := strconv.ParseUint(s, 16, 32)
+	return rune(r)
+}
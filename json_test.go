@@ -0,0 +1,228 @@
+package readonly_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleJSONTokenizer() {
+	t := readonly.NewJSONTokenizer(`{"name": "a\tb", "tags": [1, true, null]}`)
+
+	for {
+		tok, err := t.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		fmt.Printf("%s %s %q\n", tok.Kind, tok.Raw, tok.Unquote())
+	}
+	// Output:
+	// BeginObject { "{"
+	// Key "name" "name"
+	// String "a\tb" "a\tb"
+	// Key "tags" "tags"
+	// BeginArray [ "["
+	// Number 1 "1"
+	// Bool true "true"
+	// Null null "null"
+	// EndArray ] "]"
+	// EndObject } "}"
+}
+
+func ExampleJSONTokenizer_Path() {
+	doc := `{"users": [{"name": "alice"}, {"name": "bob", "roles": ["admin"]}]}`
+
+	v, err := readonly.NewJSONTokenizer(doc).Path("users[1].roles")
+	fmt.Println(v, err)
+	_, err = readonly.NewJSONTokenizer(doc).Path("users[2]")
+	fmt.Println(err)
+	// Output:
+	// ["admin"] <nil>
+	// readonly: JSON path not found
+}
+
+func TestJSONTokenizer_Path(t *testing.T) {
+	doc := `{"a": {"b\"": [10, {"c": "d"}, [], "e"], "f": null}, "g": -1.5e3, "h.i[0]\\": 1} 7`
+
+	for _, c := range []struct {
+		query, expected string
+		err             error
+	}{
+		{"", `{"a": {"b\"": [10, {"c": "d"}, [], "e"], "f": null}, "g": -1.5e3, "h.i[0]\\": 1}`, nil},
+		{"g", `-1.5e3`, nil},
+		{"a.f", `null`, nil},
+		{`a.b"[1].c`, `"d"`, nil},
+		{`a.b"[2]`, `[]`, nil},
+		{`a.b"[3]`, `"e"`, nil},
+		{`a.b"[4]`, ``, readonly.ErrJSONPathNotFound},
+		{`a.b"[0].c`, ``, readonly.ErrJSONPathNotFound},
+		{"a[0]", ``, readonly.ErrJSONPathNotFound},
+		{"x", ``, readonly.ErrJSONPathNotFound},
+		{`h\.i\[0]\\`, `1`, nil},
+		{`h.i[0]`, ``, readonly.ErrJSONPathNotFound},
+	} {
+		tok := readonly.NewJSONTokenizer(doc)
+		v, err := tok.Path(c.query)
+		if !errors.Is(err, c.err) || v.String() != c.expected {
+			t.Fatalf("%q: expected %q %v, got %q %v", c.query, c.expected, c.err, v, err)
+		}
+		if err == nil && c.query != "" {
+			for tok.Depth() > 0 && err == nil { // the rest of the document.
+				_, err = tok.Next()
+			}
+			if n, err := tok.Next(); err != nil || n.Raw.String() != "7" {
+				t.Fatalf("%q: expected the next value, got %v %v", c.query, n, err)
+			}
+		}
+	}
+
+	for _, query := range []string{".a", "a.", "a..b", "a.[0]", "[x]", "[-1]", "[0", "[0]a", `a\`} {
+		if _, err := readonly.NewJSONTokenizer(doc).Path(query); err == nil || errors.Is(err, readonly.ErrJSONPathNotFound) {
+			t.Fatalf("%q: expected invalid path error, got %v", query, err)
+		}
+	}
+
+	var syntaxErr *readonly.JSONSyntaxError
+	if _, err := readonly.NewJSONTokenizer(`{"a": [1 2], "b": 1}`).Path("b"); !errors.As(err, &syntaxErr) || syntaxErr.Offset != 9 {
+		t.Fatalf("expected syntax error at offset 9, got %v", err)
+	}
+}
+
+func TestJSONTokenizer_ZeroCopy(t *testing.T) {
+	doc := []byte(`["plain", "esc\n"]`)
+	tok := readonly.NewJSONTokenizer(doc)
+	_, _ = tok.Next()
+	plain, _ := tok.Next()
+	escaped, _ := tok.Next()
+
+	doc[2] = 'P'
+	if plain.Unquote().String() != "Plain" || escaped.Unquote().String() != "esc\n" {
+		t.Fatalf("unexpected values %q %q", plain.Unquote(), escaped.Unquote())
+	}
+	if v := plain.Value(); v.Cap() != v.Len() {
+		t.Fatalf("the value must be limited to its length, got cap %d", v.Cap())
+	}
+}
+
+var jsonInputs = []string{
+	``, ` `, `1`, `-0.5e+10`, `01`, `1.`, `-`, `1e`, `"a"`, `"😀 \ud800 é \x"`,
+	"\"\xff\"", "\"\x01\"", `"\"\\\/\b\f\n\r\t"`, `"abc`, `"\`, `"\u12"`,
+	`true`, `tru`, `nul`, `[]`, `{}`, `[1,]`, `[,1]`, `{"a"}`, `{"a":}`, `{"a":1,}`, `{1:2}`,
+	`[1 2]`, `{"a":1 "b":2}`, `[}`, `{]`, `[[[]]]`, `{"a":{"b":[{"c":null}]}}`, `1 2`, `[1]]`, ` [ "a" , { } ] `,
+}
+
+func FuzzJSONTokenizer(f *testing.F) {
+	for _, input := range jsonInputs {
+		f.Add(input)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		valid := json.Valid([]byte(input))
+		tok := readonly.NewJSONTokenizer(input)
+		dec := json.NewDecoder(strings.NewReader(input))
+		dec.UseNumber()
+
+		for i := 0; ; i++ {
+			actual, err := tok.Next()
+			if err != nil {
+				if valid && (!errors.Is(err, io.EOF) || i == 0 && strings.TrimSpace(input) != "") {
+					t.Fatalf("%q: unexpected error %v", input, err)
+				}
+				// The tokenizer accepts streams of values, like json.Decoder.
+				if !valid && errors.Is(err, io.EOF) && !validJSONStream(input) {
+					t.Fatalf("%q: expected syntax error, got %v", input, err)
+				}
+				return
+			}
+			if !valid {
+				continue
+			}
+
+			expected, err := dec.Token()
+			if err != nil {
+				t.Fatalf("%q: unexpected decoder error %v", input, err)
+			}
+			if s := jsonTokenString(actual); s != fmt.Sprint(expected) {
+				t.Fatalf("%q: token %d: expected %v, got %s", input, i, expected, s)
+			}
+		}
+	})
+}
+
+func TestJSONTokenizer(t *testing.T) {
+	for _, input := range jsonInputs {
+		valid := json.Valid([]byte(input))
+		tok := readonly.NewJSONTokenizer(input)
+		err := tok.Skip()
+		if err == nil {
+			if _, err = tok.Next(); errors.Is(err, io.EOF) {
+				err = nil
+			} else if err == nil {
+				err = errors.New("another value")
+			}
+		}
+		if valid != (err == nil) {
+			t.Fatalf("%q: expected valid %v, got %v", input, valid, err)
+		}
+	}
+}
+
+// validJSONStream reports whether input is a sequence of valid JSON
+// values.
+func validJSONStream(input string) bool {
+	dec := json.NewDecoder(strings.NewReader(input))
+	for {
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return errors.Is(err, io.EOF)
+		}
+	}
+}
+
+func jsonTokenString(tok readonly.JSONToken) string {
+	switch tok.Kind {
+	case readonly.JSONBeginObject, readonly.JSONEndObject, readonly.JSONBeginArray, readonly.JSONEndArray,
+		readonly.JSONNumber:
+		return tok.Raw.String()
+	case readonly.JSONBool:
+		return fmt.Sprint(tok.Bool())
+	case readonly.JSONNull:
+		return "<nil>"
+	}
+	return tok.Unquote().String()
+}
+
+func BenchmarkJSONTokenizer(b *testing.B) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i := 0; i < limit/100; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"id": %d, "name": "user %d", "tags": ["a", "b\n"], "active": true}`, i, i)
+	}
+	buf.WriteByte(']')
+	input := buf.Bytes()
+
+	b.Run("readonly", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			tok := readonly.NewJSONTokenizer(input)
+			for _, err := tok.Next(); err == nil; _, err = tok.Next() {
+			}
+		}
+	})
+	b.Run("encoding/json", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dec := json.NewDecoder(bytes.NewReader(input))
+			for _, err := dec.Token(); err == nil; _, err = dec.Token() {
+			}
+		}
+	})
+}
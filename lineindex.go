@@ -0,0 +1,75 @@
+package readonly
+
+import (
+	"bytes"
+	"sort"
+)
+
+// NewLineIndex scans b once and returns an index of its lines for
+// random access. Lines are split like bufio.ScanLines does: the
+// trailing \n or \r\n is not a part of a line, and the last line may
+// have no line break, in which case its trailing \r is dropped.
+func NewLineIndex(b ByteSlice) *LineIndex {
+	starts := make([]int, 0, 1+len(b.s)/64)
+	for i := 0; i < len(b.s); {
+		starts = append(starts, i)
+		j := bytes.IndexByte(b.s[i:], '\n')
+		if j < 0 {
+			break
+		}
+		i += j + 1
+	}
+	return &LineIndex{b.s, starts}
+}
+
+// LineIndex is an index of the lines of a ByteSlice, see NewLineIndex.
+type LineIndex struct {
+	s      []byte
+	starts []int // offsets of the beginnings of the lines.
+}
+
+// LineCount returns the number of lines.
+func (x *LineIndex) LineCount() int { return len(x.starts) }
+
+// Offset returns the offset of the beginning of the line i.
+// Panics if i is out of range.
+func (x *LineIndex) Offset(i int) int { return x.starts[i] }
+
+// Line returns a view of the line i without the line break.
+// Panics if i is out of range.
+func (x *LineIndex) Line(i int) ByteSlice {
+	start, end := x.starts[i], len(x.s)
+	if i+1 < len(x.starts) {
+		end = x.starts[i+1]
+	}
+	if end > start && x.s[end-1] == '\n' {
+		end--
+	}
+	if end > start && x.s[end-1] == '\r' { // even without \n, like bufio.ScanLines.
+		end--
+	}
+	return ByteSlice{Slice[byte]{x.s[start:end:end]}}
+}
+
+// LineOf returns the index of the line containing the byte at offset.
+// A line break belongs to the line it ends.
+// Panics if offset is out of range.
+func (x *LineIndex) LineOf(offset int) int {
+	if offset < 0 || offset >= len(x.s) {
+		panic("readonly.LineIndex.LineOf: offset out of range")
+	}
+	return sort.SearchInts(x.starts, offset+1) - 1
+}
+
+// Lines returns views of the lines in the range [from, to).
+// Panics if the range is out of range.
+func (x *LineIndex) Lines(from, to int) Slice[ByteSlice] {
+	if from < 0 || to < from || to > len(x.starts) {
+		panic("readonly.LineIndex.Lines: range out of range")
+	}
+	lines := make([]ByteSlice, to-from)
+	for i := range lines {
+		lines[i] = x.Line(from + i)
+	}
+	return Slice[ByteSlice]{lines}
+}
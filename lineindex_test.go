@@ -0,0 +1,74 @@
+package readonly_test
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleLineIndex() {
+	x := readonly.NewLineIndex(readonly.NewByteSlice("first\nsecond\r\n\nfourth"))
+
+	fmt.Println(x.LineCount(), x.Line(1), x.LineOf(8))
+	x.Lines(2, 4).Range(func(i int, line readonly.ByteSlice) bool {
+		fmt.Printf("%q\n", line)
+		return true
+	})
+	// Output:
+	// 4 second 1
+	// ""
+	// "fourth"
+}
+
+func TestLineIndex(t *testing.T) {
+	for _, input := range []string{
+		"", "\n", "a", "a\n", "a\r\nb", "\n\na\n\nb\r\r\n", strings.Repeat("line\n", 100),
+		"\r", "a\r", "a\nb\r", "a\r\r", "\r\n\r", "a\r\n\r",
+	} {
+		x := readonly.NewLineIndex(readonly.NewByteSlice(input))
+
+		var expected []string
+		for s := bufio.NewScanner(strings.NewReader(input)); s.Scan(); {
+			expected = append(expected, s.Text())
+		}
+		if x.LineCount() != len(expected) {
+			t.Fatalf("%q: expected %d lines, got %d", input, len(expected), x.LineCount())
+		}
+		for i, line := range expected {
+			if x.Line(i).String() != line || x.Lines(i, i+1).Get(0).String() != line {
+				t.Fatalf("%q: line %d: expected %q, got %q", input, i, line, x.Line(i))
+			}
+		}
+		if x.Lines(0, x.LineCount()).Len() != len(expected) {
+			t.Fatalf("%q: unexpected number of lines", input)
+		}
+
+		for offset := range input {
+			i := x.LineOf(offset)
+			if offset < x.Offset(i) || i+1 < x.LineCount() && offset >= x.Offset(i+1) {
+				t.Fatalf("%q: unexpected line %d of offset %d", input, i, offset)
+			}
+		}
+	}
+
+	x := readonly.NewLineIndex(readonly.NewByteSlice("a\nb"))
+	if line := x.Line(0); line.Cap() != line.Len() {
+		t.Fatalf("the line must be limited to its length, got cap %d", line.Cap())
+	}
+	mustPanic(t, func() { x.Line(2) })
+	mustPanic(t, func() { x.LineOf(3) })
+	mustPanic(t, func() { x.LineOf(-1) })
+	mustPanic(t, func() { x.Lines(1, 0) })
+	mustPanic(t, func() { x.Lines(0, 3) })
+}
+
+func BenchmarkNewLineIndex(b *testing.B) {
+	input := readonly.NewByteSlice(strings.Repeat("2006-01-02 15:04:05 INFO some log message\n", limit))
+	b.SetBytes(int64(input.Len()))
+	for i := 0; i < b.N; i++ {
+		readonly.NewLineIndex(input)
+	}
+}
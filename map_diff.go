@@ -0,0 +1,126 @@
+package readonly
+
+// Equal reports whether a and b contain the same key-value pairs.
+// A nil map and an empty map are considered equal.
+func Equal[K, V comparable](a, b Map[K, V]) bool {
+	return EqualFunc(a, b, func(v1, v2 V) bool { return v1 == v2 })
+}
+
+// EqualFunc is like Equal, but compares values using eq.
+// Keys are still compared with ==.
+func EqualFunc[K comparable, V1, V2 any](a Map[K, V1], b Map[K, V2], eq func(V1, V2) bool) bool {
+	if len(a.m) != len(b.m) {
+		return false
+	}
+	for key, v1 := range a.m {
+		if v2, ok := b.m[key]; !ok || !eq(v1, v2) {
+			return false
+		}
+	}
+	return true
+}
+
+// Diff returns the changes that turn from into to.
+func Diff[K, V comparable](from, to Map[K, V]) MapDiff[K, V] {
+	return DiffFunc(from, to, func(v1, v2 V) bool { return v1 == v2 })
+}
+
+// DiffFunc is like Diff, but compares values using eq.
+func DiffFunc[K comparable, V any](from, to Map[K, V], eq func(V, V) bool) (d MapDiff[K, V]) {
+	for key, v1 := range from.m {
+		switch v2, ok := to.m[key]; {
+		case !ok:
+			d.removed = put(d.removed, key, v1)
+		case !eq(v1, v2):
+			d.changed = put(d.changed, key, v2)
+		}
+	}
+	for key, v2 := range to.m {
+		if _, ok := from.m[key]; !ok {
+			d.added = put(d.added, key, v2)
+		}
+	}
+	return d
+}
+
+func put[K comparable, V any](m map[K]V, key K, val V) map[K]V {
+	if m == nil {
+		m = make(map[K]V)
+	}
+	m[key] = val
+	return m
+}
+
+// MapDiff describes the difference between two maps, see Diff.
+// The zero value describes no changes.
+type MapDiff[K comparable, V any] struct{ added, removed, changed map[K]V }
+
+// Added returns the keys that are present only in the new map, with
+// their new values.
+func (d MapDiff[K, V]) Added() Map[K, V] { return Map[K, V]{d.added} }
+
+// Removed returns the keys that are present only in the old map, with
+// their old values.
+func (d MapDiff[K, V]) Removed() Map[K, V] { return Map[K, V]{d.removed} }
+
+// Changed returns the keys that are present in both maps with different
+// values, with their new values.
+func (d MapDiff[K, V]) Changed() Map[K, V] { return Map[K, V]{d.changed} }
+
+// IsEmpty reports whether the maps were equal.
+func (d MapDiff[K, V]) IsEmpty() bool {
+	return len(d.added) == 0 && len(d.removed) == 0 && len(d.changed) == 0
+}
+
+// Patch returns the operations that turn the old map into the new one.
+// Since every key occurs at most once, the order of operations
+// doesn't matter and is unspecified.
+func (d MapDiff[K, V]) Patch() Patch[K, V] {
+	p := make(Patch[K, V], 0, len(d.added)+len(d.removed)+len(d.changed))
+	for key, val := range d.added {
+		p = append(p, PatchOp[K, V]{Action: PatchSet, Key: key, Value: val})
+	}
+	for key, val := range d.changed {
+		p = append(p, PatchOp[K, V]{Action: PatchSet, Key: key, Value: val})
+	}
+	for key := range d.removed {
+		p = append(p, PatchOp[K, V]{Action: PatchDelete, Key: key})
+	}
+	return p
+}
+
+// PatchAction is the kind of PatchOp.
+type PatchAction string
+
+// Actions supported by PatchOp.
+const (
+	PatchSet    PatchAction = "set"
+	PatchDelete PatchAction = "delete"
+)
+
+// PatchOp is a single change of a map. Value is ignored by PatchDelete.
+type PatchOp[K comparable, V any] struct {
+	Action PatchAction `json:"op"`
+	Key    K           `json:"key"`
+	Value  V           `json:"value,omitempty"`
+}
+
+// Patch is a list of map changes, see MapDiff.Patch.
+// It is serialized to JSON as an array of
+// {"op": "set" | "delete", "key": ..., "value": ...} objects,
+// so it can be logged and replayed later.
+type Patch[K comparable, V any] []PatchOp[K, V]
+
+// Apply applies the operations to m in order.
+// Operations with an unknown action are ignored.
+// Panics if m == nil and p contains PatchSet, like assignment to a nil map.
+func (p Patch[K, V]) Apply(m map[K]V) {
+	for i := range p {
+		switch p[i].Action {
+		case PatchSet:
+			m[p[i].Key] = p[i].Value
+		case PatchDelete:
+			delete(m, p[i].Key)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package readonly_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleEqual() {
+	a := readonly.NewMap(map[string]int{"1": 1, "2": 2})
+	b := readonly.NewMap(map[string]int{"1": 1, "2": 3})
+
+	fmt.Println(readonly.Equal(a, a), readonly.Equal(a, b))
+	fmt.Println(readonly.Equal(readonly.NewMap[string, int](nil), readonly.NewMap(map[string]int{})))
+	// Output:
+	// true false
+	// true
+}
+
+func ExampleDiff() {
+	from := readonly.NewMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	to := readonly.NewMap(map[string]int{"b": 2, "c": 4, "d": 5})
+
+	d := readonly.Diff(from, to)
+	fmt.Println(d.Added().Get2("d"))
+	fmt.Println(d.Removed().Get2("a"))
+	fmt.Println(d.Changed().Get2("c"))
+	fmt.Println(d.Changed().Has("b"), d.IsEmpty())
+	// Output:
+	// 5 true
+	// 1 true
+	// 4 true
+	// false false
+}
+
+func ExamplePatch_Apply() {
+	from := map[string]int{"a": 1, "b": 2}
+	to := map[string]int{"b": 3}
+
+	p := readonly.Diff(readonly.NewMap(from), readonly.NewMap(to)).Patch()
+	p.Apply(from)
+	fmt.Println(from)
+	// Output:
+	// map[b:3]
+}
+
+func TestEqualFunc(t *testing.T) {
+	a := readonly.NewMap(map[int]string{1: "1", 2: "2"})
+	b := readonly.NewMap(map[int]int{1: 1, 2: 2})
+	eq := func(s string, i int) bool { return s == fmt.Sprint(i) }
+
+	if !readonly.EqualFunc(a, b, eq) {
+		t.Fatalf("expected %v and %v to be equal", a, b)
+	}
+
+	for i, c := range []readonly.Map[int, int]{
+		readonly.NewMap(map[int]int{1: 1}),
+		readonly.NewMap(map[int]int{1: 1, 3: 2}),
+		readonly.NewMap(map[int]int{1: 1, 2: 3}),
+		readonly.NewMap(map[int]int{1: 1, 2: 2, 3: 3}),
+	} {
+		if readonly.EqualFunc(a, c, eq) {
+			t.Fatalf("[%d] expected %v and %v not to be equal", i, a, c)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	m := readonly.NewMap(map[string]int{"a": 1})
+	for i, d := range []readonly.MapDiff[string, int]{
+		{},
+		readonly.Diff(m, m),
+		readonly.Diff(readonly.NewMap[string, int](nil), readonly.NewMap(map[string]int{})),
+	} {
+		if !d.IsEmpty() || !d.Added().IsNil() || !d.Removed().IsNil() || !d.Changed().IsNil() {
+			t.Fatalf("[%d] expected empty diff, got %v", i, d)
+		}
+		if p := d.Patch(); len(p) != 0 {
+			t.Fatalf("[%d] expected empty patch, got %v", i, p)
+		}
+	}
+}
+
+func TestPatch_JSON(t *testing.T) {
+	from := map[string][]int{"a": {1}, "b": {2}, "c": nil}
+	to := map[string][]int{"b": {2, 3}, "c": nil, "d": {4}}
+	eq := func(a, b []int) bool { return fmt.Sprint(a) == fmt.Sprint(b) }
+
+	data, err := json.Marshal(readonly.DiffFunc(readonly.NewMap(from), readonly.NewMap(to), eq).Patch())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	var p readonly.Patch[string, []int]
+	if err = json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(p) != 3 {
+		t.Fatalf("expected 3 operations, got %s", data)
+	}
+
+	p.Apply(from)
+	if !readonly.EqualFunc(readonly.NewMap(from), readonly.NewMap(to), eq) {
+		t.Fatalf("expected %v, got %v", to, from)
+	}
+}
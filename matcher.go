@@ -0,0 +1,176 @@
+package readonly
+
+import (
+	"errors"
+	"io"
+)
+
+// NewMatcher returns an Aho-Corasick automaton searching for all the
+// patterns at once. Empty patterns never match.
+func NewMatcher(patterns Slice[ByteSlice]) *Matcher { return newMatcher(patterns, false) }
+
+// NewFoldMatcher returns a Matcher with ASCII case-insensitive
+// patterns.
+func NewFoldMatcher(patterns Slice[ByteSlice]) *Matcher { return newMatcher(patterns, true) }
+
+// Matcher is a compiled set of patterns, see NewMatcher.
+// It's immutable and safe for concurrent use.
+type Matcher struct {
+	class   [256]uint16 // input bytes are mapped to classes of equal bytes.
+	classes int
+	delta   []int32    // transitions of the states, classes per state.
+	outs    [][2]int32 // ranges of out with the matched patterns of the states.
+	out     []int32    // indices of the patterns.
+	lens    []int      // lengths of the patterns.
+}
+
+// Match is a match of a pattern of a Matcher.
+type Match struct {
+	Pattern    int // index of the pattern.
+	Start, End int // offsets of the match.
+}
+
+func newMatcher(patterns Slice[ByteSlice], fold bool) *Matcher {
+	m := &Matcher{lens: make([]int, len(patterns.s))}
+
+	// Class 0 is for the bytes that aren't in any pattern.
+	var seen [256]bool
+	for _, p := range patterns.s {
+		for _, c := range p.s {
+			if fold {
+				c = toLower(c)
+			}
+			seen[c] = true
+		}
+	}
+	m.classes = 1
+	for c := range seen {
+		if seen[c] {
+			m.class[c] = uint16(m.classes)
+			m.classes++
+		}
+	}
+	if fold {
+		for c := 'A'; c <= 'Z'; c++ {
+			m.class[c] = m.class[c+'a'-'A']
+		}
+	}
+
+	// The trie. 0 is an absent transition, since nothing leads to
+	// the root.
+	m.delta = make([]int32, m.classes)
+	own := [][]int32{nil} // patterns ending in the states.
+	for i, p := range patterns.s {
+		m.lens[i] = len(p.s)
+		if len(p.s) == 0 {
+			continue
+		}
+		var s int32
+		for _, c := range p.s {
+			t := m.delta[int(s)*m.classes+int(m.class[c])]
+			if t == 0 {
+				t = int32(len(own))
+				own = append(own, nil)
+				m.delta[int(s)*m.classes+int(m.class[c])] = t
+				m.delta = append(m.delta, make([]int32, m.classes)...)
+			}
+			s = t
+		}
+		own[s] = append(own[s], int32(i))
+	}
+
+	// Failure links are resolved into transitions in BFS order, so
+	// the transitions and the matches of a failure state are complete
+	// before use.
+	fail := make([]int32, len(own))
+	m.outs = make([][2]int32, len(own))
+	for queue := []int32{0}; len(queue) > 0; queue = queue[1:] {
+		s := queue[0]
+
+		start := len(m.out)
+		m.out = append(m.out, own[s]...)
+		if s != 0 {
+			r := m.outs[fail[s]]
+			m.out = append(m.out, m.out[r[0]:r[1]]...)
+		}
+		m.outs[s] = [2]int32{int32(start), int32(len(m.out))}
+
+		row := m.delta[int(s)*m.classes : int(s+1)*m.classes]
+		for c, t := range row {
+			f := m.delta[int(fail[s])*m.classes+c]
+			if t == 0 {
+				row[c] = f
+				continue
+			}
+			if s != 0 {
+				fail[t] = f
+			}
+			queue = append(queue, t)
+		}
+	}
+	return m
+}
+
+// FindAll returns all the possibly overlapping matches in b in order of
+// their ends.
+func (m *Matcher) FindAll(b ByteSlice) Slice[Match] {
+	var matches []Match
+	var s int32
+	for i, c := range b.s {
+		s = m.delta[int(s)*m.classes+int(m.class[c])]
+		if r := m.outs[s]; r[0] != r[1] {
+			for _, p := range m.out[r[0]:r[1]] {
+				matches = append(matches, Match{int(p), i + 1 - m.lens[p], i + 1})
+			}
+		}
+	}
+	return Slice[Match]{matches}
+}
+
+// Contains reports whether any pattern is in b. It stops at the
+// first match.
+func (m *Matcher) Contains(b ByteSlice) bool {
+	var s int32
+	for _, c := range b.s {
+		s = m.delta[int(s)*m.classes+int(m.class[c])]
+		if r := m.outs[s]; r[0] != r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// FindReader calls f for all the possibly overlapping matches in the
+// data read from r in order of their ends. The offsets are from the
+// beginning of the data. Matches spanning several reads are found,
+// and the data is not retained.
+// Breaks the loop if next == false.
+func (m *Matcher) FindReader(r io.Reader, f func(match Match) (next bool)) error {
+	var (
+		buf = make([]byte, 32<<10)
+		rd  Reader
+		s   int32
+		off int
+	)
+	for {
+		n, err := r.Read(buf)
+		ResetReader(&rd, buf[:n])
+		for c, e := rd.ReadByte(); e == nil; c, e = rd.ReadByte() {
+			off++
+			s = m.delta[int(s)*m.classes+int(m.class[c])]
+			if r := m.outs[s]; r[0] != r[1] {
+				for _, p := range m.out[r[0]:r[1]] {
+					if !f(Match{int(p), off - m.lens[p], off}) {
+						return nil
+					}
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package readonly_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleMatcher() {
+	m := readonly.NewFoldMatcher(readonly.NewSlice([]readonly.ByteSlice{
+		readonly.NewByteSlice("he"),
+		readonly.NewByteSlice("she"),
+		readonly.NewByteSlice("hers"),
+	}))
+
+	text := readonly.NewByteSlice("USHERS")
+	m.FindAll(text).Range(func(_ int, match readonly.Match) bool {
+		fmt.Println(match.Pattern, text.String()[match.Start:match.End])
+		return true
+	})
+	fmt.Println(m.Contains(readonly.NewByteSlice("shoe")))
+	// Output:
+	// 1 SHE
+	// 0 HE
+	// 2 HERS
+	// false
+}
+
+func TestMatcher(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		patterns := make([]readonly.ByteSlice, rnd.Intn(10))
+		for j := range patterns {
+			patterns[j] = readonly.NewByteSlice(randomText(rnd, "abcAB", rnd.Intn(5)))
+		}
+		text := randomText(rnd, "abcABd", rnd.Intn(200))
+
+		for _, fold := range []bool{false, true} {
+			var m *readonly.Matcher
+			if fold {
+				m = readonly.NewFoldMatcher(readonly.NewSlice(patterns))
+			} else {
+				m = readonly.NewMatcher(readonly.NewSlice(patterns))
+			}
+
+			expected := naiveMatches(patterns, text, fold)
+			actual := m.FindAll(readonly.NewByteSlice(text)).Copy()
+			if fmt.Sprint(sortedMatches(actual)) != fmt.Sprint(expected) {
+				t.Fatalf("%q in %q (fold %v): expected %v, got %v", patterns, text, fold, expected, actual)
+			}
+			for j := 1; j < len(actual); j++ {
+				if actual[j].End < actual[j-1].End {
+					t.Fatalf("matches must be ordered by end: %v", actual)
+				}
+			}
+			if m.Contains(readonly.NewByteSlice(text)) != (len(expected) > 0) {
+				t.Fatalf("%q in %q (fold %v): expected Contains %v", patterns, text, fold, len(expected) > 0)
+			}
+
+			var streamed []readonly.Match
+			err := m.FindReader(iotest.HalfReader(strings.NewReader(text)), func(match readonly.Match) bool {
+				streamed = append(streamed, match)
+				return true
+			})
+			if err != nil || fmt.Sprint(streamed) != fmt.Sprint(actual) {
+				t.Fatalf("%q in %q (fold %v): expected streamed %v, got %v %v", patterns, text, fold, actual, streamed, err)
+			}
+		}
+	}
+}
+
+func TestMatcher_FindReader(t *testing.T) {
+	m := readonly.NewMatcher(readonly.NewSlice([]readonly.ByteSlice{readonly.NewByteSlice("ab")}))
+
+	var calls int
+	err := m.FindReader(strings.NewReader(strings.Repeat("ab", 100)), func(readonly.Match) bool {
+		calls++
+		return calls < 3
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("expected 3 calls, got %d %v", calls, err)
+	}
+
+	expected := errors.New("some error")
+	err = m.FindReader(iotest.DataErrReader(iotest.ErrReader(expected)), func(readonly.Match) bool { return true })
+	if !errors.Is(err, expected) {
+		t.Fatalf("expected %v, got %v", expected, err)
+	}
+
+	// Matches spanning the internal buffer.
+	text := strings.Repeat("x", 32<<10-1) + "ab"
+	var found []readonly.Match
+	err = m.FindReader(strings.NewReader(text), func(match readonly.Match) bool {
+		found = append(found, match)
+		return true
+	})
+	if err != nil || len(found) != 1 || found[0].Start != len(text)-2 {
+		t.Fatalf("expected a match at %d, got %v %v", len(text)-2, found, err)
+	}
+}
+
+func TestMatcher_Concurrent(t *testing.T) {
+	m := readonly.NewMatcher(readonly.NewSlice([]readonly.ByteSlice{readonly.NewByteSlice("needle")}))
+	text := readonly.NewByteSlice(strings.Repeat("hay needle ", 100))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if n := m.FindAll(text).Len(); n != 100 {
+				t.Errorf("expected 100 matches, got %d", n)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func naiveMatches(patterns []readonly.ByteSlice, text string, fold bool) (matches []readonly.Match) {
+	for i, p := range patterns {
+		if p.Len() == 0 {
+			continue
+		}
+		for start := 0; start+p.Len() <= len(text); start++ {
+			s := text[start : start+p.Len()]
+			if s == p.String() || fold && strings.EqualFold(s, p.String()) {
+				matches = append(matches, readonly.Match{Pattern: i, Start: start, End: start + p.Len()})
+			}
+		}
+	}
+	return sortedMatches(matches)
+}
+
+func sortedMatches(matches []readonly.Match) []readonly.Match {
+	res := append([]readonly.Match(nil), matches...)
+	for i := 1; i < len(res); i++ {
+		for j := i; j > 0 && lessMatch(res[j], res[j-1]); j-- {
+			res[j], res[j-1] = res[j-1], res[j]
+		}
+	}
+	return res
+}
+
+func lessMatch(a, b readonly.Match) bool {
+	if a.Start != b.Start {
+		return a.Start < b.Start
+	}
+	return a.Pattern < b.Pattern
+}
+
+func BenchmarkMatcher(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	patterns := make([]readonly.ByteSlice, 1000)
+	for i := range patterns {
+		patterns[i] = readonly.NewByteSlice(randomText(rnd, "abcdefghijklmnopqrstuvwxyz", 8))
+	}
+	text := []byte(randomText(rnd, "abcdefghijklmnopqrstuvwxyz ", limit))
+	m := readonly.NewMatcher(readonly.NewSlice(patterns))
+
+	b.Run("Matcher", func(b *testing.B) {
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			m.Contains(readonly.NewByteSlice(text))
+		}
+	})
+	b.Run("bytes.Contains", func(b *testing.B) {
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			for _, p := range patterns {
+				if bytes.Contains(text, []byte(p.String())) {
+					break
+				}
+			}
+		}
+	})
+}
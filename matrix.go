@@ -0,0 +1,110 @@
+package readonly
+
+// NewMatrix returns a read-only view of the first rows*cols elements
+// of s as a matrix stored in row-major order.
+// Panics if rows or cols is negative or s is too short.
+func NewMatrix[T any](s Slice[T], rows, cols int) Matrix[T] {
+	if rows < 0 || cols < 0 || rows*cols > len(s.s) {
+		panic("readonly.NewMatrix: invalid dimensions")
+	}
+	return Matrix[T]{s: s.s[: rows*cols : rows*cols], rows: rows, cols: cols, rowStride: cols, colStride: 1}
+}
+
+// Matrix is a read-only two-dimensional view over a Slice.
+// Col, SubMatrix and Transpose don't copy the elements, nor does Row
+// unless the matrix is transposed.
+type Matrix[T any] struct {
+	s                    []T
+	off, rows, cols      int
+	rowStride, colStride int
+}
+
+// Rows returns the number of rows.
+func (m Matrix[T]) Rows() int { return m.rows }
+
+// Cols returns the number of columns.
+func (m Matrix[T]) Cols() int { return m.cols }
+
+// At returns the element at row r and column c.
+// Panics if r or c is out of range.
+func (m Matrix[T]) At(r, c int) T {
+	m.checkRow(r)
+	m.checkCol(c)
+	return m.s[m.index(r, c)]
+}
+
+// Row returns a view of the row r. The elements are copied only if the
+// matrix is transposed, since its rows are not contiguous then.
+// Panics if r is out of range.
+func (m Matrix[T]) Row(r int) Slice[T] {
+	m.checkRow(r)
+	row := m.line(m.index(r, 0), m.cols, m.colStride)
+	if m.colStride == 1 || m.cols <= 1 {
+		return row
+	}
+	return Materialize[T](Strided[T](row, m.colStride))
+}
+
+// Col returns a strided view of the column c.
+// Panics if c is out of range.
+func (m Matrix[T]) Col(c int) StridedView[T, Slice[T]] {
+	m.checkCol(c)
+	step := m.rowStride
+	if m.rows <= 1 {
+		step = 1
+	}
+	return Strided[T](m.line(m.index(0, c), m.rows, step), step)
+}
+
+// SubMatrix returns a view of rows*cols elements starting with row r
+// and column c.
+// Panics if the sub-matrix is out of range.
+func (m Matrix[T]) SubMatrix(r, c, rows, cols int) Matrix[T] {
+	if r < 0 || c < 0 || rows < 0 || cols < 0 || r+rows > m.rows || c+cols > m.cols {
+		panic("readonly.Matrix: sub-matrix out of range")
+	}
+	m.off, m.rows, m.cols = m.index(r, c), rows, cols
+	return m
+}
+
+// Transpose returns a view of the transposed matrix.
+func (m Matrix[T]) Transpose() Matrix[T] {
+	m.rows, m.cols = m.cols, m.rows
+	m.rowStride, m.colStride = m.colStride, m.rowStride
+	return m
+}
+
+// Copy returns a new copy of the elements in row-major order.
+func (m Matrix[T]) Copy() []T {
+	s := make([]T, 0, m.rows*m.cols)
+	for r := 0; r < m.rows; r++ {
+		for c := 0; c < m.cols; c++ {
+			s = append(s, m.s[m.index(r, c)])
+		}
+	}
+	return s
+}
+
+func (m Matrix[T]) index(r, c int) int { return m.off + r*m.rowStride + c*m.colStride }
+
+func (m Matrix[T]) checkRow(r int) {
+	if r < 0 || r >= m.rows {
+		panic("readonly.Matrix: row index out of range")
+	}
+}
+
+func (m Matrix[T]) checkCol(c int) {
+	if c < 0 || c >= m.cols {
+		panic("readonly.Matrix: column index out of range")
+	}
+}
+
+// line returns a view of the elements from the first to the last of n
+// elements starting with off with step between them.
+func (m Matrix[T]) line(off, n, step int) Slice[T] {
+	if n == 0 {
+		return Slice[T]{}
+	}
+	end := off + (n-1)*step + 1
+	return Slice[T]{m.s[off:end:end]}
+}
@@ -0,0 +1,119 @@
+package readonly_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleNewMatrix() {
+	grid := [6]float64{
+		1, 2, 3,
+		4, 5, 6,
+	}
+	m := readonly.NewMatrix(readonly.NewSlice(grid[:]), 2, 3)
+
+	fmt.Println(m.Rows(), m.Cols(), m.At(1, 2))
+	fmt.Println(m.Row(1).Copy())
+	fmt.Println(readonly.Materialize[float64](m.Col(1)).Copy())
+	fmt.Println(m.Transpose().Copy())
+	fmt.Println(m.SubMatrix(0, 1, 2, 2).Copy())
+	// Output:
+	// 2 3 6
+	// [4 5 6]
+	// [2 5]
+	// [1 4 2 5 3 6]
+	// [2 3 5 6]
+}
+
+func TestMatrix(t *testing.T) {
+	data := make([]int, 20)
+	for i := range data {
+		data[i] = i
+	}
+	s := readonly.NewSlice(data)
+
+	for rows := 0; rows <= 4; rows++ {
+		for cols := 0; cols <= 5; cols++ {
+			m := readonly.NewMatrix(s, rows, cols)
+			mt := m.Transpose()
+			if mt.Rows() != cols || mt.Cols() != rows {
+				t.Fatalf("[%dx%d] unexpected transposed dimensions %dx%d", rows, cols, mt.Rows(), mt.Cols())
+			}
+
+			for r := 0; r < rows; r++ {
+				row, tcol := m.Row(r), mt.Col(r)
+				if row.Len() != cols || tcol.Len() != cols {
+					t.Fatalf("[%dx%d] unexpected length of row %d: %d", rows, cols, r, row.Len())
+				}
+				for c := 0; c < cols; c++ {
+					expected := r*cols + c
+					if m.At(r, c) != expected || mt.At(c, r) != expected ||
+						row.Get(c) != expected || tcol.Get(c) != expected ||
+						m.Col(c).Get(r) != expected || mt.Row(c).Get(r) != expected {
+						t.Fatalf("[%dx%d] expected %d at %d,%d", rows, cols, expected, r, c)
+					}
+				}
+			}
+
+			for r := 0; r <= rows; r++ {
+				for c := 0; c <= cols; c++ {
+					sub := m.SubMatrix(r, c, rows-r, cols-c)
+					for i := 0; i < sub.Rows(); i++ {
+						for j := 0; j < sub.Cols(); j++ {
+							if sub.At(i, j) != m.At(r+i, c+j) || sub.Transpose().At(j, i) != m.At(r+i, c+j) {
+								t.Fatalf("[%dx%d] unexpected sub-matrix at %d,%d", rows, cols, r, c)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Rows are views that can't be resliced beyond their length.
+	row := m3x3(s).Row(1)
+	if row.Cap() != 3 {
+		t.Fatalf("expected capacity 3, got %d", row.Cap())
+	}
+	if data[3] = -1; row.Get(0) != -1 {
+		t.Fatal("expected the row to share memory with the matrix")
+	}
+	data[3] = 3
+	if n := testing.AllocsPerRun(10, func() { m3x3(s).Row(1); m3x3(s).Col(1) }); n != 0 {
+		t.Fatalf("expected no allocations, got %v", n)
+	}
+
+	m := m3x3(s)
+	mustPanic(t, func() { readonly.NewMatrix(s, 5, 5) })
+	mustPanic(t, func() { readonly.NewMatrix(s, -1, 0) })
+	mustPanic(t, func() { m.At(3, 0) })
+	mustPanic(t, func() { m.At(0, -1) })
+	mustPanic(t, func() { m.Row(3) })
+	mustPanic(t, func() { m.Col(3) })
+	mustPanic(t, func() { m.SubMatrix(1, 1, 3, 1) })
+	mustPanic(t, func() { readonly.NewMatrix(s, 0, 3).Row(0) })
+}
+
+func m3x3(s readonly.Slice[int]) readonly.Matrix[int] { return readonly.NewMatrix(s, 3, 3) }
+
+func BenchmarkMatrix_Col(b *testing.B) {
+	const rows, cols = 1000, 100
+	m := readonly.NewMatrix(readonly.NewSlice(make([]float64, rows*cols)), rows, cols)
+
+	b.Run("Col", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var sum float64
+			m.Col(cols / 2).Range(func(_ int, v float64) bool { sum += v; return true })
+		}
+	})
+	b.Run("At", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var sum float64
+			for r := 0; r < rows; r++ {
+				sum += m.At(r, cols/2)
+			}
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package readonly
+
+import (
+	"io"
+	"net"
+	"sort"
+	"unicode/utf8"
+)
+
+// NewMultiReader returns a MultiReader that reads the concatenation
+// of parts without copying them.
+func NewMultiReader(parts ...ByteSlice) *MultiReader {
+	r := &MultiReader{}
+	for _, p := range parts {
+		if len(p.s) > 0 {
+			r.parts = append(r.parts, p.s)
+			r.offsets = append(r.offsets, r.size)
+			r.size += int64(len(p.s))
+		}
+	}
+	return r
+}
+
+// MultiReader implements the io.Reader, io.ByteReader, io.RuneReader,
+// io.ReaderAt and io.WriterTo interfaces by reading from a sequence
+// of byte slices as if they were one.
+// The zero value for MultiReader operates like a Reader of an empty
+// string.
+type MultiReader struct {
+	parts   [][]byte // non-empty parts.
+	offsets []int64  // offsets[i] is the offset of parts[i] in the input.
+	size    int64
+
+	i, off int // the next unread byte is parts[i][off].
+}
+
+// Len returns the number of bytes of the unread portion of the input.
+func (r *MultiReader) Len() int {
+	if r.i == len(r.parts) {
+		return 0
+	}
+	return int(r.size - r.offsets[r.i] - int64(r.off))
+}
+
+// Size returns the total length of the input.
+func (r *MultiReader) Size() int64 { return r.size }
+
+// Read implements the io.Reader interface.
+func (r *MultiReader) Read(p []byte) (n int, err error) {
+	if r.i == len(r.parts) {
+		return 0, io.EOF
+	}
+	for n < len(p) && r.i < len(r.parts) {
+		m := copy(p[n:], r.parts[r.i][r.off:])
+		n += m
+		r.skip(m)
+	}
+	return n, nil
+}
+
+// ReadByte implements the io.ByteReader interface.
+func (r *MultiReader) ReadByte() (b byte, err error) {
+	if r.i == len(r.parts) {
+		return 0, io.EOF
+	}
+	b = r.parts[r.i][r.off]
+	r.skip(1)
+	return b, nil
+}
+
+// ReadRune implements the io.RuneReader interface.
+// Runes split between parts are decoded as a whole.
+func (r *MultiReader) ReadRune() (ch rune, size int, err error) {
+	if r.i == len(r.parts) {
+		return 0, 0, io.EOF
+	}
+
+	p := r.parts[r.i][r.off:]
+	switch {
+	case p[0] < utf8.RuneSelf:
+		ch, size = rune(p[0]), 1
+	case utf8.FullRune(p):
+		ch, size = utf8.DecodeRune(p)
+	default:
+		var buf [utf8.UTFMax]byte
+		n := len(p)
+		copy(buf[:], p)
+		for i := r.i + 1; n < len(buf) && i < len(r.parts); i++ {
+			n += copy(buf[n:], r.parts[i])
+		}
+		ch, size = utf8.DecodeRune(buf[:n])
+	}
+
+	r.skip(size)
+	return ch, size, nil
+}
+
+// ReadAt implements the io.ReaderAt interface.
+// It doesn't affect and isn't affected by the other methods.
+func (r *MultiReader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errNegativeOffset
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	i := sort.Search(len(r.offsets), func(i int) bool { return r.offsets[i] > off }) - 1
+	for o := int(off - r.offsets[i]); n < len(p) && i < len(r.parts); i, o = i+1, 0 {
+		n += copy(p[n:], r.parts[i][o:])
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// WriteTo implements the io.WriterTo interface.
+// The parts are written with net.Buffers, so if w is a net.Conn that
+// supports it, they are sent with a single writev system call.
+// w must not modify the slice data, even temporarily, see io.Writer.
+func (r *MultiReader) WriteTo(w io.Writer) (n int64, err error) {
+	if r.i == len(r.parts) {
+		return 0, nil
+	}
+
+	size := int64(r.Len())
+	bufs := make(net.Buffers, 0, len(r.parts)-r.i)
+	bufs = append(bufs, r.parts[r.i][r.off:])
+	bufs = append(bufs, r.parts[r.i+1:]...)
+
+	n, err = bufs.WriteTo(w)
+	r.skip(int(n))
+
+	if err == nil && n != size {
+		err = io.ErrShortWrite
+	}
+	return n, err
+}
+
+// skip consumes n bytes, possibly crossing the part boundaries.
+func (r *MultiReader) skip(n int) {
+	for r.off += n; r.i < len(r.parts) && r.off >= len(r.parts[r.i]); r.i++ {
+		r.off -= len(r.parts[r.i])
+	}
+}
@@ -0,0 +1,145 @@
+package readonly_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleNewMultiReader() {
+	r := readonly.NewMultiReader(
+		readonly.NewByteSlice("HTTP/1.1 200 OK\r\n\r\n"),
+		readonly.NewByteSlice([]byte("hello, ")),
+		readonly.NewByteSlice("world"),
+	)
+
+	var buf strings.Builder
+	n, err := r.WriteTo(&buf)
+	fmt.Printf("%d %v %q\n", n, err, buf.String())
+	// Output:
+	// 31 <nil> "HTTP/1.1 200 OK\r\n\r\nhello, world"
+}
+
+func parts(s string, sizes ...int) []readonly.ByteSlice {
+	var p []readonly.ByteSlice
+	for _, size := range sizes {
+		p, s = append(p, readonly.NewByteSlice(s[:size])), s[size:]
+	}
+	return append(p, readonly.NewByteSlice(s))
+}
+
+func TestMultiReader_ReadRune(t *testing.T) {
+	const text = "aфы😀b"
+
+	// Split the text at every possible byte, including inside runes.
+	for i := 0; i <= len(text); i++ {
+		for j := i; j <= len(text); j++ {
+			r := readonly.NewMultiReader(parts(text, i, j-i)...)
+
+			var actual []rune
+			for c, _, err := r.ReadRune(); !errors.Is(err, io.EOF); c, _, err = r.ReadRune() {
+				actual = append(actual, c)
+			}
+			if string(actual) != text {
+				t.Fatalf("[%d:%d] expected %q, got %q", i, j, text, string(actual))
+			}
+		}
+	}
+}
+
+func TestMultiReader_ReadAt(t *testing.T) {
+	const text = "0123456789"
+	r := readonly.NewMultiReader(parts(text, 0, 3, 1, 4)...)
+
+	for off := 0; off <= len(text); off++ {
+		for size := 0; size <= len(text)+1; size++ {
+			expected := make([]byte, size)
+			expectedN, expectedErr := strings.NewReader(text).ReadAt(expected, int64(off))
+
+			actual := make([]byte, size)
+			n, err := r.ReadAt(actual, int64(off))
+			if n != expectedN || !errors.Is(err, expectedErr) || !bytes.Equal(actual, expected) {
+				t.Fatalf("[%d:%d] expected %d %v %q, got %d %v %q",
+					off, size, expectedN, expectedErr, expected, n, err, actual)
+			}
+		}
+	}
+
+	if _, err := r.ReadAt(nil, -1); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestMultiReader_WriteTo(t *testing.T) {
+	r := readonly.NewMultiReader(parts("some text", 2, 3)...)
+	if b, err := r.ReadByte(); b != 's' || err != nil {
+		t.Fatalf("expected 's', got %q %v", b, err)
+	}
+
+	var limit writer = func(p []byte) (int, error) {
+		if len(p) > 3 {
+			return 3, errors.New("limit exceeded")
+		}
+		return len(p), nil
+	}
+	n, err := r.WriteTo(limit)
+	if n != 7 || err == nil || r.Len() != 1 {
+		t.Fatalf("expected 7 bytes written with an error, got %d %v, %d left", n, err, r.Len())
+	}
+
+	var buf bytes.Buffer
+	if n, err = r.WriteTo(&buf); n != 1 || err != nil || buf.String() != "t" {
+		t.Fatalf("expected %q, got %d %v %q", "t", n, err, buf.String())
+	}
+	if n, err = r.WriteTo(&buf); n != 0 || err != nil {
+		t.Fatalf("expected nothing to be written, got %d %v", n, err)
+	}
+
+	var dontWrite writer = func([]byte) (int, error) { return 0, nil }
+	if _, err = readonly.NewMultiReader(parts("text")...).WriteTo(dontWrite); !errors.Is(err, io.ErrShortWrite) {
+		t.Fatalf("expected %q, got %q", io.ErrShortWrite, err)
+	}
+}
+
+func TestMultiReader_WriteToConn(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+
+	go func() {
+		defer server.Close()
+		_, _ = readonly.NewMultiReader(parts("some text", 2, 3)...).WriteTo(server)
+	}()
+
+	actual, err := io.ReadAll(client)
+	if err != nil || string(actual) != "some text" {
+		t.Fatalf("expected %q, got %q %v", "some text", actual, err)
+	}
+}
+
+func BenchmarkMultiReader(b *testing.B) {
+	fragments := make([]readonly.ByteSlice, 64)
+	for i := range fragments {
+		fragments[i] = readonly.NewByteSlice(strings.Repeat("x", 1<<10))
+	}
+
+	b.Run("readonly.MultiReader", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = readonly.NewMultiReader(fragments...).WriteTo(io.Discard)
+		}
+	})
+	b.Run("io.MultiReader", func(b *testing.B) {
+		readers := make([]io.Reader, len(fragments))
+		for i := 0; i < b.N; i++ {
+			for j := range fragments {
+				readers[j] = readonly.NewReader(fragments[j])
+			}
+			_, _ = io.Copy(io.Discard, io.MultiReader(readers...))
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package readonly
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Number is a constraint that permits any numeric type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~complex64 | ~complex128
+}
+
+// Sum returns the sum of the elements of s.
+func Sum[T Number](s Slice[T]) (sum T) {
+	for i := range s.s {
+		sum += s.s[i]
+	}
+	return sum
+}
+
+// Reduce combines the elements of s in order, starting with init.
+func Reduce[T, U any](s Slice[T], init U, f func(acc U, val T) U) U {
+	for i := range s.s {
+		init = f(init, s.s[i])
+	}
+	return init
+}
+
+// ParallelRange calls f for every element of s from several
+// goroutines. s is split into a chunk view per worker, every chunk is
+// iterated in order by a single goroutine.
+// workers <= 0 means runtime.GOMAXPROCS(0).
+//
+// The first error returned by f cancels the context passed to the
+// other calls and is returned. If f panics, the panic is propagated to
+// the caller of ParallelRange. If ctx is canceled, the iteration stops
+// and ctx.Err() is returned.
+func ParallelRange[T any](
+	ctx context.Context, s Slice[T], workers int, f func(ctx context.Context, index int, val T) error,
+) error {
+	return parallel(ctx, len(s.s), workers, func(ctx context.Context, start, end int) error {
+		for i := start; i < end; i++ {
+			if (i-start)%ctxCheckInterval == 0 && ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err := f(ctx, i, s.s[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ParallelMap returns a new Slice of f applied to the elements of s,
+// computed like in ParallelRange.
+func ParallelMap[T, U any](
+	ctx context.Context, s Slice[T], workers int, f func(ctx context.Context, val T) (U, error),
+) (Slice[U], error) {
+	res := make([]U, len(s.s))
+	err := ParallelRange(ctx, s, workers, func(ctx context.Context, index int, val T) (err error) {
+		res[index], err = f(ctx, val)
+		return err
+	})
+	if err != nil {
+		return Slice[U]{}, err
+	}
+	return Slice[U]{res}, nil
+}
+
+// ParallelReduce combines the elements of s in parallel: every chunk
+// is reduced starting with identity, and then the results of the
+// chunks are combined in order. combine must be associative, and
+// identity must be its identity element, e.g. 0 for addition.
+// The only possible error is the one of a canceled ctx.
+func ParallelReduce[T any](
+	ctx context.Context, s Slice[T], workers int, identity T, combine func(a, b T) T,
+) (T, error) {
+	workers = workerCount(len(s.s), workers)
+	res := make([]T, workers)
+	size := chunkSize(len(s.s), workers)
+	err := parallel(ctx, len(s.s), workers, func(ctx context.Context, start, end int) error {
+		acc := identity
+		for i := start; i < end; i++ {
+			if (i-start)%ctxCheckInterval == 0 && ctx.Err() != nil {
+				return ctx.Err()
+			}
+			acc = combine(acc, s.s[i])
+		}
+		res[start/size] = acc
+		return nil
+	})
+	if err != nil {
+		return identity, err
+	}
+	return Reduce(Slice[T]{res}, identity, combine), nil
+}
+
+// ctxCheckInterval is the number of elements between the checks of the
+// context cancellation.
+const ctxCheckInterval = 256
+
+func workerCount(n, workers int) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return workers
+}
+
+func chunkSize(n, workers int) int {
+	if size := (n + workers - 1) / workers; size > 0 {
+		return size
+	}
+	return 1
+}
+
+// PanicError wraps a value of a panic recovered in a worker goroutine
+// of ParallelRange and the like, which is then re-panicked in the
+// calling goroutine.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (p *PanicError) Error() string { return fmt.Sprintf("panic in worker: %v\n%s", p.Value, p.Stack) }
+
+// parallel calls f for [start, end) chunks of n elements from workers
+// goroutines.
+func parallel(ctx context.Context, n, workers int, f func(ctx context.Context, start, end int) error) error {
+	if err := ctx.Err(); err != nil || n == 0 {
+		return err
+	}
+
+	workers = workerCount(n, workers)
+	size := chunkSize(n, workers)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		first    error
+		panicked *PanicError // set instead of first if the first failure is a panic.
+	)
+	fail := func(err error, p *PanicError) { once.Do(func() { first, panicked = err, p; cancel() }) }
+
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() {
+				if v := recover(); v != nil {
+					buf := make([]byte, 64<<10)
+					fail(nil, &PanicError{Value: v, Stack: buf[:runtime.Stack(buf, false)]})
+				}
+			}()
+			if err := f(ctx, start, end); err != nil {
+				fail(err, nil)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	if panicked != nil {
+		panic(panicked)
+	}
+	return first
+}
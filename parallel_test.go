@@ -0,0 +1,156 @@
+package readonly_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleParallelMap() {
+	s := readonly.NewSlice([]int{1, 2, 3, 4, 5})
+
+	squares, err := readonly.ParallelMap(context.Background(), s, 2, func(_ context.Context, v int) (int, error) {
+		return v * v, nil
+	})
+	fmt.Println(squares.Copy(), err)
+	fmt.Println(readonly.Sum(squares))
+	// Output:
+	// [1 4 9 16 25] <nil>
+	// 55
+}
+
+func ExampleParallelReduce() {
+	s := readonly.NewSlice([]string{"a", "b", "c", "d", "e"})
+
+	concat := func(a, b string) string { return a + b }
+	res, err := readonly.ParallelReduce(context.Background(), s, 3, "", concat)
+	fmt.Println(res, err)
+	fmt.Println(readonly.Reduce(s, ">", concat))
+	// Output:
+	// abcde <nil>
+	// >abcde
+}
+
+func TestParallelRange(t *testing.T) {
+	for _, size := range []int{0, 1, 7, 1000} {
+		for _, workers := range []int{-1, 1, 3, 2000} {
+			var (
+				s     = readonly.NewSlice(rand.Perm(size))
+				count int64
+				sum   int64
+			)
+			err := readonly.ParallelRange(context.Background(), s, workers, func(_ context.Context, i, v int) error {
+				if s.Get(i) != v {
+					return fmt.Errorf("unexpected value %d at %d", v, i)
+				}
+				atomic.AddInt64(&count, 1)
+				atomic.AddInt64(&sum, int64(v))
+				return nil
+			})
+			if err != nil || count != int64(size) || sum != int64(readonly.Sum(s)) {
+				t.Fatalf("[%d/%d] expected %d calls, got %d %v", size, workers, size, count, err)
+			}
+		}
+	}
+}
+
+func TestParallelRange_Error(t *testing.T) {
+	s := readonly.NewSlice(make([]int, 10000))
+	expected := errors.New("some error")
+
+	var calls int64
+	err := readonly.ParallelRange(context.Background(), s, 4, func(ctx context.Context, i, _ int) error {
+		atomic.AddInt64(&calls, 1)
+		if i == 0 {
+			return expected
+		}
+		if i%2500 == 0 {
+			<-ctx.Done() // the other workers are canceled.
+		}
+		return nil
+	})
+	if !errors.Is(err, expected) {
+		t.Fatalf("expected %q, got %v", expected, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = readonly.ParallelMap(ctx, s, 4, func(context.Context, int) (int, error) { return 0, nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %q, got %v", context.Canceled, err)
+	}
+	_, err = readonly.ParallelReduce(ctx, s, 4, 0, func(a, b int) int { return a + b })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %q, got %v", context.Canceled, err)
+	}
+}
+
+func TestParallelRange_Panic(t *testing.T) {
+	defer func() {
+		var p *readonly.PanicError
+		if err, ok := recover().(error); !ok || !errors.As(err, &p) || p.Value != "some panic" {
+			t.Fatalf("expected the worker panic to be propagated, got %v", err)
+		}
+	}()
+
+	s := readonly.NewSlice(make([]int, 100))
+	_ = readonly.ParallelRange(context.Background(), s, 4, func(_ context.Context, i, _ int) error {
+		if i == 50 {
+			panic("some panic")
+		}
+		return nil
+	})
+}
+
+func TestParallelRange_PanicErrorReturned(t *testing.T) {
+	expected := &readonly.PanicError{Value: "not a panic"}
+	s := readonly.NewSlice(make([]int, 100))
+	err := readonly.ParallelRange(context.Background(), s, 4, func(_ context.Context, i, _ int) error {
+		if i == 50 {
+			return expected
+		}
+		return nil
+	})
+	if !errors.Is(err, expected) {
+		t.Fatalf("expected the returned error %v, got %v", expected, err)
+	}
+}
+
+func TestParallelReduce(t *testing.T) {
+	for _, size := range []int{0, 1, 7, 1000} {
+		s := readonly.NewSlice(rand.Perm(size))
+		for _, workers := range []int{0, 1, 3, 2000} {
+			res, err := readonly.ParallelReduce(context.Background(), s, workers, 0, func(a, b int) int { return a + b })
+			if expected := readonly.Sum(s); res != expected || err != nil {
+				t.Fatalf("[%d/%d] expected %d, got %d %v", size, workers, expected, res, err)
+			}
+		}
+	}
+}
+
+func BenchmarkParallelReduce(b *testing.B) {
+	s := readonly.NewSlice(rand.Perm(limit * 10))
+	add := func(a, b int) int { return a + b }
+
+	b.Run("Range", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var sum int
+			s.Range(func(_ int, v int) bool { sum += v; return true })
+		}
+	})
+	b.Run("Reduce", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			readonly.Reduce(s, 0, add)
+		}
+	})
+	b.Run("ParallelReduce", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = readonly.ParallelReduce(context.Background(), s, 0, 0, add)
+		}
+	})
+}
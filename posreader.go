@@ -0,0 +1,135 @@
+package readonly
+
+import (
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// NewPosReader returns a new PosReader reading from src.
+func NewPosReader[T ~string | ~[]byte | ByteSlice](src T) *PosReader {
+	return &PosReader{r: Reader{s: *(*string)(unsafe.Pointer(&src))}}
+}
+
+// PosReader is a Reader that tracks the position of the unread
+// portion of the input, e.g. for parser error messages.
+// The zero value for PosReader operates like a PosReader of an empty
+// string.
+type PosReader struct {
+	r   Reader
+	pos pos
+}
+
+// Pos is a position in the input.
+type Pos struct {
+	// Offset is the byte offset, starting at 0.
+	Offset int
+
+	// Line is the line number, starting at 1.
+	Line int
+
+	// Column is the rune offset in the line, starting at 1.
+	// The column is advanced by every byte that isn't a UTF-8
+	// continuation byte, so for valid UTF-8 it counts runes regardless
+	// of whether they are read by bytes or by runes.
+	Column int
+}
+
+// String returns the position in "line:column" format.
+func (p Pos) String() string { return strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column) }
+
+// pos is a zero based Pos, so that the zero value is valid.
+type pos struct{ off, line, col int }
+
+func (p *pos) advance(s string) {
+	p.off += len(s)
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		p.line += strings.Count(s[:i+1], "\n")
+		p.col, s = 0, s[i+1:]
+	}
+	for i := 0; i < len(s); i++ {
+		if !isContinuation(s[i]) {
+			p.col++
+		}
+	}
+}
+
+func isContinuation(b byte) bool { return b&0xC0 == 0x80 }
+
+// Mark is a saved state of a PosReader, see PosReader.Mark.
+type Mark struct {
+	s   string
+	pos pos
+}
+
+// Pos returns the position of the next unread byte.
+func (r *PosReader) Pos() Pos {
+	return Pos{Offset: r.pos.off, Line: r.pos.line + 1, Column: r.pos.col + 1}
+}
+
+// Mark returns the current state of the reader, which can be restored
+// by Reset for backtracking.
+func (r *PosReader) Mark() Mark { return Mark{s: r.r.s, pos: r.pos} }
+
+// Reset restores the state saved by Mark.
+// The zero Mark resets the reader to an empty input.
+func (r *PosReader) Reset(m Mark) { r.r.s, r.pos = m.s, m.pos }
+
+// Len returns the number of bytes of the unread portion of the input.
+func (r *PosReader) Len() int { return len(r.r.s) }
+
+// Read implements the io.Reader interface.
+func (r *PosReader) Read(p []byte) (n int, err error) {
+	s := r.r.s
+	n, err = r.r.Read(p)
+	r.pos.advance(s[:n])
+	return n, err
+}
+
+// ReadByte implements the io.ByteReader interface.
+func (r *PosReader) ReadByte() (b byte, err error) {
+	if b, err = r.r.ReadByte(); err != nil {
+		return b, err
+	}
+
+	r.pos.off++
+	switch {
+	case b == '\n':
+		r.pos.line++
+		r.pos.col = 0
+	case !isContinuation(b):
+		r.pos.col++
+	}
+	return b, nil
+}
+
+// ReadRune implements the io.RuneReader interface.
+func (r *PosReader) ReadRune() (ch rune, size int, err error) {
+	s := r.r.s
+	if ch, size, err = r.r.ReadRune(); err != nil {
+		return ch, size, err
+	}
+
+	r.pos.off += size
+	switch {
+	case ch == '\n':
+		r.pos.line++
+		r.pos.col = 0
+	case !isContinuation(s[0]):
+		r.pos.col++
+	}
+	return ch, size, nil
+}
+
+// ReadSlice is like Reader.ReadSlice.
+func (r *PosReader) ReadSlice(delim byte) (line ByteSlice, err error) {
+	s, err := r.ReadString(delim)
+	return NewByteSlice(s), err
+}
+
+// ReadString is like Reader.ReadString.
+func (r *PosReader) ReadString(delim byte) (line string, err error) {
+	line, err = r.r.ReadString(delim)
+	r.pos.advance(line)
+	return line, err
+}
@@ -0,0 +1,118 @@
+package readonly_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleReader_ReadString() {
+	r := readonly.NewReader("a,b")
+	for {
+		s, err := r.ReadString(',')
+		fmt.Printf("%q %v\n", s, err)
+		if err != nil {
+			break
+		}
+	}
+	// Output:
+	// "a," <nil>
+	// "b" EOF
+}
+
+func ExamplePosReader() {
+	r := readonly.NewPosReader("key = значение\nbroken")
+
+	_, _ = r.ReadString('=')
+	fmt.Println(r.Pos())
+
+	m := r.Mark()
+	_, _ = r.ReadString('\n')
+	fmt.Println(r.Pos())
+
+	r.Reset(m)
+	fmt.Println(r.Pos(), r.Pos().Offset)
+	// Output:
+	// 1:6
+	// 2:1
+	// 1:6 5
+}
+
+func TestPosReader(t *testing.T) {
+	const text = "first line\nвторая строка\n\n\xff\x80 end"
+
+	// expected position after each byte.
+	var expected []readonly.Pos
+	line, col := 1, 1
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\n':
+			line, col = line+1, 1
+		case c&0xC0 != 0x80:
+			col++
+		}
+		expected = append(expected, readonly.Pos{Offset: i + 1, Line: line, Column: col})
+	}
+
+	check := func(name string, r *readonly.PosReader) {
+		t.Helper()
+		if p := r.Pos(); p.Offset > 0 && p != expected[p.Offset-1] {
+			t.Fatalf("%s: expected %+v, got %+v", name, expected[p.Offset-1], p)
+		}
+	}
+
+	for name, read := range map[string]func(r *readonly.PosReader) error{
+		"ReadByte": func(r *readonly.PosReader) error { _, err := r.ReadByte(); return err },
+		"ReadRune": func(r *readonly.PosReader) error { _, _, err := r.ReadRune(); return err },
+		"Read":     func(r *readonly.PosReader) error { _, err := r.Read(make([]byte, 3)); return err },
+		"ReadSlice": func(r *readonly.PosReader) error {
+			_, err := r.ReadSlice(' ')
+			return err
+		},
+	} {
+		r := readonly.NewPosReader(text)
+		for {
+			err := read(r)
+			check(name, r)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+		}
+		if p := r.Pos(); p != expected[len(expected)-1] {
+			t.Fatalf("%s: expected %+v at the end, got %+v", name, expected[len(expected)-1], p)
+		}
+	}
+
+	var r readonly.PosReader
+	if _, err := r.ReadByte(); !errors.Is(err, io.EOF) || r.Pos() != (readonly.Pos{Line: 1, Column: 1}) {
+		t.Fatalf("expected EOF at 1:1, got %v at %v", err, r.Pos())
+	}
+}
+
+func BenchmarkPosReader(b *testing.B) {
+	data := strings.Repeat("some line of text\n", 1<<12)
+
+	b.Run("readonly.Reader", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r := readonly.NewReader(data)
+			_, _ = rf.ReadFrom(r)
+		}
+	})
+	b.Run("readonly.PosReader", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r := readonly.NewPosReader(data)
+			_, _ = rf.ReadFrom(r)
+		}
+	})
+	b.Run("readonly.PosReader.ReadByte", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r := readonly.NewPosReader(data)
+			for _, err := r.ReadByte(); err == nil; _, err = r.ReadByte() {
+			}
+		}
+	})
+}
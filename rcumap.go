@@ -0,0 +1,59 @@
+package readonly
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// NewRCUMap returns an RCUMap initialized with a copy of m.
+func NewRCUMap[K comparable, V any](m map[K]V) *RCUMap[K, V] {
+	r := &RCUMap[K, V]{}
+	r.v.Store(clone(m))
+	return r
+}
+
+// RCUMap is a read-copy-update map for read-mostly data that is
+// shared between goroutines.
+// Readers get immutable snapshots without locking, writers never
+// modify a published map, but replace it with an updated copy.
+// The zero value is an empty map ready to use.
+// An RCUMap must not be copied after first use.
+type RCUMap[K comparable, V any] struct {
+	mu sync.Mutex // serializes writers.
+	v  atomic.Value
+}
+
+// Snapshot returns the current state of the map.
+// The snapshot never changes, even if the map is updated later.
+// It is safe to call Snapshot concurrently with Update.
+func (r *RCUMap[K, V]) Snapshot() Map[K, V] {
+	m, _ := r.v.Load().(map[K]V)
+	return Map[K, V]{m: m}
+}
+
+// Update calls f with a copy of the current map and publishes the
+// result, so a batch of mutations becomes visible to readers at once.
+// Concurrent calls to Update are serialized.
+// f must not retain m after returning.
+// Does nothing if f == nil.
+func (r *RCUMap[K, V]) Update(f func(m map[K]V)) {
+	if f == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, _ := r.v.Load().(map[K]V)
+	m = clone(m)
+	f(m)
+	r.v.Store(m)
+}
+
+func clone[K comparable, V any](src map[K]V) map[K]V {
+	dst := make(map[K]V, len(src))
+	for key, val := range src {
+		dst[key] = val
+	}
+	return dst
+}
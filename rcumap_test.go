@@ -0,0 +1,114 @@
+package readonly_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleRCUMap() {
+	var m readonly.RCUMap[string, int]
+
+	before := m.Snapshot()
+	m.Update(func(m map[string]int) {
+		m["a"] = 1
+		m["b"] = 2
+	})
+	after := m.Snapshot()
+
+	fmt.Println(before.Len(), after.Len(), after.Get("b"))
+	// Output:
+	// 0 2 2
+}
+
+func TestNewRCUMap(t *testing.T) {
+	src := map[int]int{1: 1}
+	m := readonly.NewRCUMap(src)
+	src[2] = 2
+
+	if s := m.Snapshot(); s.Len() != 1 || s.Has(2) {
+		t.Fatalf("snapshot must not depend on the source map, got %v", s)
+	}
+
+	m.Update(nil) // do nothing.
+	if s := m.Snapshot(); s.Len() != 1 {
+		t.Fatalf("expected 1 element, got %v", s)
+	}
+}
+
+// Run with -race.
+func TestRCUMap_Concurrent(t *testing.T) {
+	const writers, readers, updates = 4, 8, 500
+
+	var (
+		m  readonly.RCUMap[int, int]
+		wg sync.WaitGroup
+	)
+
+	for w := 0; w < writers; w++ {
+		w := w
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < updates; i++ {
+				// Every batch keeps the invariant m[k] == k for all keys.
+				m.Update(func(m map[int]int) {
+					k := w*updates + i
+					m[k] = k
+					delete(m, k-1)
+				})
+			}
+		}()
+	}
+
+	errs := make(chan error, readers)
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < updates; i++ {
+				// Every reader sends at most one error, so it never blocks.
+				var err error
+				s := m.Snapshot()
+				n := s.Len()
+				s.Range(func(key, val int) bool {
+					if key != val {
+						err = fmt.Errorf("broken invariant: m[%d] == %d", key, val)
+						return false
+					}
+					return true
+				})
+				if err == nil && n != s.Len() {
+					err = fmt.Errorf("snapshot changed: %d != %d", n, s.Len())
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	if s := m.Snapshot(); s.Len() == 0 {
+		t.Fatalf("expected updates to be published, got %v", s)
+	}
+}
+
+func BenchmarkRCUMap_Snapshot(b *testing.B) {
+	rm := readonly.NewRCUMap(m)
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rm.Snapshot().Get(limit / 2)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package readonly
 import (
 	"io"
 	"reflect"
+	"strings"
 	"unicode/utf8"
 	"unsafe"
 )
@@ -80,3 +81,28 @@ func (r Reader) WriteTo(w io.Writer) (n int64, err error) {
 
 // ResetReader resets the Reader to be reading from b.
 func ResetReader[T []byte | string | ByteSlice](r *Reader, b T) { r.s = *(*string)(unsafe.Pointer(&b)) }
+
+// ReadSlice reads until the first occurrence of delim in the input,
+// returning a view of the data up to and including the delimiter.
+// If ReadSlice encounters the end of input before finding a delimiter,
+// it returns all the remaining data and io.EOF.
+// Unlike bufio.Reader.ReadSlice, the data is never overwritten.
+func (r *Reader) ReadSlice(delim byte) (line ByteSlice, err error) {
+	s, err := r.ReadString(delim)
+	return NewByteSlice(s), err
+}
+
+// ReadString is like ReadSlice, but returns a string.
+// The string shares memory with the input, so no allocation occurs.
+func (r *Reader) ReadString(delim byte) (line string, err error) {
+	if len(r.s) == 0 {
+		return "", io.EOF
+	}
+	i := strings.IndexByte(r.s, delim)
+	if i < 0 {
+		line, r.s = r.s, ""
+		return line, io.EOF
+	}
+	line, r.s = r.s[:i+1], r.s[i+1:]
+	return line, nil
+}
@@ -0,0 +1,194 @@
+package readonly
+
+import (
+	"io"
+	"math/bits"
+)
+
+// ropeMergeSize is the maximal total size of adjacent small leaves
+// that are copied into one leaf on concatenation.
+const ropeMergeSize = 128
+
+// NewRope returns a rope consisting of a single leaf b.
+func NewRope(b ByteSlice) Rope {
+	if len(b.s) == 0 {
+		return Rope{}
+	}
+	return Rope{&ropeNode{leaf: b.s, size: len(b.s), leaves: 1}}
+}
+
+// Rope is an immutable sequence of bytes made of ByteSlice leaves,
+// for large texts that are edited by creating new versions.
+// All operations return new ropes that share leaves with the
+// original, which stays unchanged. The tree is rebalanced
+// automatically, so that indexing takes O(log n) time.
+// The zero value is an empty rope.
+type Rope struct{ n *ropeNode }
+
+type ropeNode struct {
+	leaf        []byte // only for leaves.
+	left, right *ropeNode
+	size        int
+	depth       int // 0 for leaves.
+	leaves      int
+}
+
+// Len returns the number of bytes in the rope.
+func (r Rope) Len() int {
+	if r.n == nil {
+		return 0
+	}
+	return r.n.size
+}
+
+// ByteAt returns the i-th byte of the rope.
+// Panics if i is out of range.
+func (r Rope) ByteAt(i int) byte {
+	if i < 0 || i >= r.Len() {
+		panic("readonly.Rope: index out of range")
+	}
+	n := r.n
+	for n.leaf == nil {
+		if i < n.left.size {
+			n = n.left
+		} else {
+			i, n = i-n.left.size, n.right
+		}
+	}
+	return n.leaf[i]
+}
+
+// Concat returns the concatenation of r and o.
+func (r Rope) Concat(o Rope) Rope { return Rope{ropeJoin(r.n, o.n)} }
+
+// Insert returns a rope with b inserted at the byte offset i.
+// Panics if i is out of range.
+func (r Rope) Insert(i int, b ByteSlice) Rope {
+	r.check(i, i)
+	return Rope{ropeJoin(ropeJoin(r.n.slice(0, i), NewRope(b).n), r.n.slice(i, r.Len()))}
+}
+
+// Delete returns a rope without the bytes in range [i, j).
+// Panics if the range is invalid, like s[i:j].
+func (r Rope) Delete(i, j int) Rope {
+	r.check(i, j)
+	return Rope{ropeJoin(r.n.slice(0, i), r.n.slice(j, r.Len()))}
+}
+
+// Slice equivalent to s[i:j].
+func (r Rope) Slice(i, j int) Rope {
+	r.check(i, j)
+	return Rope{r.n.slice(i, j)}
+}
+
+func (r Rope) check(i, j int) {
+	if i < 0 || j < i || j > r.Len() {
+		panic("readonly.Rope: slice bounds out of range")
+	}
+}
+
+// Leaves calls f for every leaf of the rope in order.
+// Does nothing if f == nil.
+// Breaks the loop if next == false.
+func (r Rope) Leaves(f func(leaf ByteSlice) (next bool)) {
+	if f != nil {
+		r.n.walk(f)
+	}
+}
+
+// NewReader returns a reader of the rope content.
+func (r Rope) NewReader() *MultiReader {
+	if r.n == nil {
+		return &MultiReader{}
+	}
+	parts := make([]ByteSlice, 0, r.n.leaves)
+	r.n.walk(func(leaf ByteSlice) bool { parts = append(parts, leaf); return true })
+	return NewMultiReader(parts...)
+}
+
+// WriteTo implements io.WriterTo.
+// w must not modify the slice data, even temporarily, see io.Writer.
+func (r Rope) WriteTo(w io.Writer) (n int64, err error) { return r.NewReader().WriteTo(w) }
+
+// String returns the content of the rope as a string.
+func (r Rope) String() string {
+	b := make([]byte, 0, r.Len())
+	r.Leaves(func(leaf ByteSlice) bool { b = leaf.Append(b); return true })
+	return string(b)
+}
+
+func (n *ropeNode) walk(f func(leaf ByteSlice) bool) bool {
+	switch {
+	case n == nil:
+		return true
+	case n.leaf != nil:
+		return f(ByteSlice{Slice[byte]{n.leaf}})
+	}
+	return n.left.walk(f) && n.right.walk(f)
+}
+
+func (n *ropeNode) appendLeaves(dst []*ropeNode) []*ropeNode {
+	if n.leaf != nil {
+		return append(dst, n)
+	}
+	return n.right.appendLeaves(n.left.appendLeaves(dst))
+}
+
+func (n *ropeNode) slice(i, j int) *ropeNode {
+	switch {
+	case i == j:
+		return nil
+	case i == 0 && j == n.size:
+		return n
+	case n.leaf != nil:
+		return &ropeNode{leaf: n.leaf[i:j:j], size: j - i, leaves: 1}
+	case j <= n.left.size:
+		return n.left.slice(i, j)
+	case i >= n.left.size:
+		return n.right.slice(i-n.left.size, j-n.left.size)
+	}
+	return ropeJoin(n.left.slice(i, n.left.size), n.right.slice(0, j-n.left.size))
+}
+
+// ropeJoin concatenates two trees, keeping the result balanced.
+func ropeJoin(a, b *ropeNode) *ropeNode {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.leaf != nil && b.leaf != nil && a.size+b.size <= ropeMergeSize:
+		leaf := make([]byte, 0, a.size+b.size)
+		leaf = append(append(leaf, a.leaf...), b.leaf...)
+		return &ropeNode{leaf: leaf, size: len(leaf), leaves: 1}
+	case a.depth > b.depth+1:
+		return ropeBalance(newRopeNode(a.left, ropeJoin(a.right, b)))
+	case b.depth > a.depth+1:
+		return ropeBalance(newRopeNode(ropeJoin(a, b.left), b.right))
+	}
+	return newRopeNode(a, b)
+}
+
+func newRopeNode(a, b *ropeNode) *ropeNode {
+	n := &ropeNode{left: a, right: b, size: a.size + b.size, leaves: a.leaves + b.leaves}
+	if n.depth = a.depth + 1; b.depth >= a.depth {
+		n.depth = b.depth + 1
+	}
+	return n
+}
+
+// ropeBalance rebuilds the tree if it is much deeper than a perfectly
+// balanced one.
+func ropeBalance(n *ropeNode) *ropeNode {
+	if n.depth <= 2*bits.Len(uint(n.leaves))+2 {
+		return n
+	}
+	return buildRope(n.appendLeaves(make([]*ropeNode, 0, n.leaves)))
+}
+
+func buildRope(leaves []*ropeNode) *ropeNode {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	return newRopeNode(buildRope(leaves[:len(leaves)/2]), buildRope(leaves[len(leaves)/2:]))
+}
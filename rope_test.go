@@ -0,0 +1,113 @@
+package readonly_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleRope() {
+	v1 := readonly.NewRope(readonly.NewByteSlice("hello world"))
+	v2 := v1.Insert(5, readonly.NewByteSlice(","))
+	v3 := v2.Delete(7, 12).Concat(readonly.NewRope(readonly.NewByteSlice("rope")))
+
+	fmt.Println(v1)
+	fmt.Println(v2)
+	fmt.Println(v3, v3.Len(), string(v3.ByteAt(7)))
+	// Output:
+	// hello world
+	// hello, world
+	// hello, rope 11 r
+}
+
+func TestRope(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	text := func() string { return strings.Repeat(string(rune('a'+rnd.Intn(26))), 1+rnd.Intn(300)) }
+
+	var (
+		expected string
+		r        readonly.Rope
+		versions = map[string]readonly.Rope{}
+	)
+	for i := 0; i < 2000; i++ {
+		switch n := len(expected); rnd.Intn(4) {
+		case 0:
+			s := text()
+			expected, r = expected+s, r.Concat(readonly.NewRope(readonly.NewByteSlice(s)))
+		case 1:
+			s, at := text(), rnd.Intn(n+1)
+			expected, r = expected[:at]+s+expected[at:], r.Insert(at, readonly.NewByteSlice(s))
+		case 2:
+			from := rnd.Intn(n + 1)
+			to := from + rnd.Intn(n-from+1)/4
+			expected, r = expected[:from]+expected[to:], r.Delete(from, to)
+		case 3:
+			from := rnd.Intn(n + 1)
+			to := from + rnd.Intn(n-from+1)
+			if s := r.Slice(from, to).String(); s != expected[from:to] {
+				t.Fatalf("[%d] expected %q, got %q", i, expected[from:to], s)
+			}
+		}
+
+		if r.Len() != len(expected) {
+			t.Fatalf("[%d] expected length %d, got %d", i, len(expected), r.Len())
+		}
+		if len(expected) > 0 {
+			at := rnd.Intn(len(expected))
+			if r.ByteAt(at) != expected[at] {
+				t.Fatalf("[%d] expected %q at %d, got %q", i, expected[at], at, r.ByteAt(at))
+			}
+		}
+		if i%100 == 0 {
+			versions[expected] = r
+		}
+	}
+
+	// Old versions are not affected by later edits.
+	for expected, r := range versions {
+		var buf bytes.Buffer
+		if _, err := r.WriteTo(&buf); err != nil || buf.String() != expected {
+			t.Fatalf("expected %q, got %q %v", expected, buf.String(), err)
+		}
+		if actual, err := io.ReadAll(r.NewReader()); err != nil || string(actual) != expected {
+			t.Fatalf("expected %q, got %q %v", expected, actual, err)
+		}
+	}
+}
+
+func TestRope_Panics(t *testing.T) {
+	r := readonly.NewRope(readonly.NewByteSlice("abc"))
+	mustPanic(t, func() { r.ByteAt(3) })
+	mustPanic(t, func() { r.ByteAt(-1) })
+	mustPanic(t, func() { r.Insert(4, readonly.ByteSlice{}) })
+	mustPanic(t, func() { r.Delete(2, 1) })
+	mustPanic(t, func() { r.Slice(0, 4) })
+}
+
+func BenchmarkRope_Insert(b *testing.B) {
+	chunk := strings.Repeat("x", 1<<10)
+
+	b.Run("readonly.Rope", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var r readonly.Rope
+			for j := 0; j < 1000; j++ {
+				r = r.Insert(r.Len()/2, readonly.NewByteSlice(chunk))
+			}
+		}
+	})
+	b.Run("string", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var s string
+			for j := 0; j < 1000; j++ {
+				s = s[:len(s)/2] + chunk + s[len(s)/2:]
+			}
+		}
+	})
+}
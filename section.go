@@ -0,0 +1,97 @@
+package readonly
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	errWhence  = errors.New("readonly.SectionReader.Seek: invalid whence")
+	errNegSeek = errors.New("readonly.SectionReader.Seek: negative position")
+)
+
+// Section returns a SectionReader that reads b[off:off+n].
+// Panics if the section is out of range.
+func (b ByteSlice) Section(off, n int64) *SectionReader {
+	if off < 0 || n < 0 || off > int64(len(b.s)) || n > int64(len(b.s))-off {
+		panic("readonly.ByteSlice.Section: section out of range")
+	}
+	return &SectionReader{s: b.s[off : off+n : off+n]}
+}
+
+// SectionReader implements the io.Reader, io.ReaderAt, io.Seeker,
+// io.ByteReader and io.WriterTo interfaces by reading from a section
+// of a ByteSlice, like io.SectionReader, but without an indirection
+// through io.ReaderAt. The zero value for SectionReader operates like
+// a SectionReader of an empty section.
+type SectionReader struct {
+	s   []byte
+	pos int64
+}
+
+// Size returns the size of the section in bytes.
+func (r *SectionReader) Size() int64 { return int64(len(r.s)) }
+
+// Len returns the number of bytes of the unread portion of the
+// section.
+func (r *SectionReader) Len() int {
+	if r.pos >= int64(len(r.s)) {
+		return 0
+	}
+	return len(r.s) - int(r.pos)
+}
+
+// Read implements the io.Reader interface.
+func (r *SectionReader) Read(p []byte) (n int, err error) {
+	if r.pos >= int64(len(r.s)) {
+		return 0, io.EOF
+	}
+	n = copy(p, r.s[r.pos:])
+	r.pos += int64(n)
+	return n, nil
+}
+
+// ReadByte implements the io.ByteReader interface.
+func (r *SectionReader) ReadByte() (byte, error) {
+	if r.pos >= int64(len(r.s)) {
+		return 0, io.EOF
+	}
+	b := r.s[r.pos]
+	r.pos++
+	return b, nil
+}
+
+// ReadAt implements the io.ReaderAt interface.
+// off is relative to the beginning of the section.
+func (r *SectionReader) ReadAt(p []byte, off int64) (n int, err error) {
+	return ByteSlice{Slice[byte]{r.s}}.ReadAt(p, off)
+}
+
+// Seek implements the io.Seeker interface.
+func (r *SectionReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += int64(len(r.s))
+	default:
+		return 0, errWhence
+	}
+	if offset < 0 {
+		return 0, errNegSeek
+	}
+	r.pos = offset
+	return offset, nil
+}
+
+// WriteTo implements the io.WriterTo interface.
+// w must not modify the slice data, even temporarily, see io.Writer.
+func (r *SectionReader) WriteTo(w io.Writer) (n int64, err error) {
+	if r.pos >= int64(len(r.s)) {
+		return 0, nil
+	}
+	n, err = ByteSlice{Slice[byte]{r.s[r.pos:]}}.WriteTo(w)
+	r.pos += n
+	return n, err
+}
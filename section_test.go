@@ -0,0 +1,127 @@
+package readonly_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleByteSlice_Section() {
+	r := readonly.NewByteSlice("header:payload:footer").Section(7, 7)
+
+	b, _ := io.ReadAll(r)
+	fmt.Println(string(b), r.Size())
+
+	_, _ = r.Seek(-4, io.SeekEnd)
+	b, _ = io.ReadAll(r)
+	fmt.Println(string(b))
+	// Output:
+	// payload 7
+	// load
+}
+
+func TestSectionReader_Seek(t *testing.T) {
+	data := []byte("0123456789")
+	expected := io.NewSectionReader(bytes.NewReader(data), 2, 6)
+	actual := readonly.NewByteSlice(data).Section(2, 6)
+
+	for i, c := range []struct {
+		offset int64
+		whence int
+	}{
+		{0, io.SeekStart}, {3, io.SeekCurrent}, {-1, io.SeekEnd}, {10, io.SeekStart},
+		{-20, io.SeekCurrent}, {1, 42}, {2, io.SeekStart},
+	} {
+		en, eerr := expected.Seek(c.offset, c.whence)
+		an, aerr := actual.Seek(c.offset, c.whence)
+		if en != an || (eerr == nil) != (aerr == nil) {
+			t.Fatalf("[%d] expected %d %v, got %d %v", i, en, eerr, an, aerr)
+		}
+
+		eb, eerr := io.ReadAll(io.LimitReader(expected, 2))
+		ab, aerr := io.ReadAll(io.LimitReader(actual, 2))
+		if !bytes.Equal(eb, ab) || !errors.Is(aerr, eerr) {
+			t.Fatalf("[%d] expected %q %v, got %q %v", i, eb, eerr, ab, aerr)
+		}
+	}
+
+	var buf bytes.Buffer
+	_, _ = actual.Seek(1, io.SeekStart)
+	if n, err := actual.WriteTo(&buf); n != 5 || err != nil || buf.String() != "34567" || actual.Len() != 0 {
+		t.Fatalf("expected %q, got %d %v %q", "34567", n, err, buf.String())
+	}
+}
+
+func TestByteSlice_Section(t *testing.T) {
+	b := readonly.NewByteSlice("abc")
+	if r := b.Section(3, 0); r.Size() != 0 {
+		t.Fatalf("expected empty section, got %d bytes", r.Size())
+	}
+
+	mustPanic(t, func() { b.Section(-1, 1) })
+	mustPanic(t, func() { b.Section(0, 4) })
+	mustPanic(t, func() { b.Section(4, 0) })
+	mustPanic(t, func() { b.Section(1, -1) })
+}
+
+func TestSectionReader_Zip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("file.txt")
+	_, _ = w.Write([]byte("zipped content"))
+	_ = zw.Close()
+
+	archive := readonly.NewByteSlice(append([]byte("prefix"), buf.Bytes()...)).Section(6, int64(buf.Len()))
+	zr, err := zip.NewReader(archive, archive.Size())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	f, err := zr.Open("file.txt")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if b, err := io.ReadAll(f); err != nil || string(b) != "zipped content" {
+		t.Fatalf("expected %q, got %q %v", "zipped content", b, err)
+	}
+}
+
+func TestSectionReader_ServeContent(t *testing.T) {
+	content := readonly.NewByteSlice("0123456789").Section(0, 10)
+
+	req := httptest.NewRequest(http.MethodGet, "/file.txt", nil)
+	req.Header.Set("Range", "bytes=2-5")
+	rec := httptest.NewRecorder()
+	http.ServeContent(rec, req, "file.txt", time.Time{}, content)
+
+	if rec.Code != http.StatusPartialContent || rec.Body.String() != "2345" {
+		t.Fatalf("expected %q, got %d %q", "2345", rec.Code, rec.Body.String())
+	}
+}
+
+func BenchmarkSectionReader(b *testing.B) {
+	data := readonly.NewByteSlice(make([]byte, 1<<16))
+	p := make([]byte, 64)
+
+	b.Run("readonly.SectionReader", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r := data.Section(0, int64(data.Len()))
+			for _, err := r.Read(p); err == nil; _, err = r.Read(p) {
+			}
+		}
+	})
+	b.Run("io.SectionReader", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r := io.NewSectionReader(data, 0, int64(data.Len()))
+			for _, err := r.Read(p); err == nil; _, err = r.Read(p) {
+			}
+		}
+	})
+}
@@ -2,6 +2,7 @@
 package readonly
 
 // NewSlice returns a slice interface limited to read-only methods.
+// Use NewSlice(a[:]) to view an array a without copying it.
 func NewSlice[T any](s []T) Slice[T] { return Slice[T]{s: s} }
 
 // Slice wrapper over a built-in slice that limits the interface
@@ -0,0 +1,453 @@
+package readonly
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"sync/atomic"
+)
+
+var errSuffixIndexData = errors.New("readonly.SuffixIndex.ReadFrom: index of different data")
+
+// NewSuffixIndex returns a suffix array of b for fast substring search,
+// similar to index/suffixarray.Index, but b is not copied.
+// The array is built in linear time.
+func NewSuffixIndex(b ByteSlice) *SuffixIndex {
+	x := &SuffixIndex{data: b.s}
+	x.alloc()
+	if x.sa32 != nil {
+		sais(b.s, x.sa32, 256)
+	} else {
+		sais(b.s, x.sa64, 256)
+	}
+	return x
+}
+
+// LoadSuffixIndex returns an index of b read from r, written by
+// SuffixIndex.WriteTo for the same data.
+func LoadSuffixIndex(b ByteSlice, r io.Reader) (*SuffixIndex, error) {
+	x := &SuffixIndex{data: b.s}
+	if _, err := x.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// SuffixIndex is a suffix array of a ByteSlice, see NewSuffixIndex.
+// It's immutable and safe for concurrent use, except for ReadFrom,
+// which may only be called before the index is shared.
+type SuffixIndex struct {
+	data []byte
+	sa32 []int32 // the suffix array if len(data) <= math.MaxInt32.
+	sa64 []int64 // the suffix array otherwise.
+
+	anchored atomic.Value // *anchoredRegexp of the last FindAllRegexp.
+}
+
+// anchoredRegexp is a regexp and its form matching only at the
+// beginning of the input.
+type anchoredRegexp struct{ r, anchored *regexp.Regexp }
+
+// Lookup returns the unsorted indices of at most n occurrences of
+// pattern in the data, all of them if n < 0.
+// An empty pattern matches nothing.
+func (x *SuffixIndex) Lookup(pattern ByteSlice, n int) Slice[int] {
+	if len(pattern.s) == 0 || n == 0 {
+		return Slice[int]{}
+	}
+	i, j := x.lookup(pattern.s)
+	if n < 0 || n > j-i {
+		n = j - i
+	}
+	res := make([]int, n)
+	for k := range res {
+		res[k] = x.at(i + k)
+	}
+	return Slice[int]{res}
+}
+
+// Count returns the number of possibly overlapping occurrences of
+// pattern in the data.
+// An empty pattern matches nothing.
+func (x *SuffixIndex) Count(pattern ByteSlice) int {
+	if len(pattern.s) == 0 {
+		return 0
+	}
+	i, j := x.lookup(pattern.s)
+	return j - i
+}
+
+// FindAllRegexp returns a sorted list of at most n non-overlapping
+// matches of r in the data as [start, end) pairs, all of them if n < 0.
+// Like suffixarray.Index.FindAllIndex, it's fast only if r has a
+// literal prefix. The matches are leftmost-longest if r.Longest was
+// called.
+func (x *SuffixIndex) FindAllRegexp(r *regexp.Regexp, n int) Slice[[2]int] {
+	if n == 0 {
+		return Slice[[2]int]{}
+	}
+
+	prefix, complete := r.LiteralPrefix()
+	if prefix == "" {
+		return Slice[[2]int]{pairs(r.FindAllIndex(x.data, n))}
+	}
+
+	// Anchored searches from the occurrences of the prefix: the
+	// anchored regexp finds the occurrences starting a match, and r
+	// finds the end of the match in its own mode.
+	lit := NewByteSlice(prefix)
+	var anchored *regexp.Regexp
+	if !complete {
+		anchored = x.anchor(r)
+	}
+	var res [][2]int
+	for n1 := n; ; n1 += 2 * (n - len(res)) {
+		indices := x.Lookup(lit, n1).s
+		sort.Ints(indices)
+		res = res[:0]
+		prev := 0
+		for _, i := range indices {
+			if len(res) == n {
+				break
+			}
+			if i < prev { // ignore overlapping matches.
+				continue
+			}
+			m := [2]int{i, i + len(prefix)}
+			if !complete {
+				if !anchored.Match(x.data[i:]) {
+					continue
+				}
+				m[1] = i + r.FindIndex(x.data[i:])[1]
+			}
+			res = append(res, m)
+			prev = m[1]
+		}
+		if n < 0 || len(res) >= n || len(indices) != n1 {
+			return Slice[[2]int]{res}
+		}
+	}
+}
+
+// anchor returns the anchored form of r. It's kept for the last r, so
+// repeated searches with the same regexp don't compile it again.
+func (x *SuffixIndex) anchor(r *regexp.Regexp) *regexp.Regexp {
+	if a, ok := x.anchored.Load().(*anchoredRegexp); ok && a.r == r {
+		return a.anchored
+	}
+	a := &anchoredRegexp{r, regexp.MustCompile("^(?:" + r.String() + ")")}
+	x.anchored.Store(a)
+	return a.anchored
+}
+
+// WriteTo writes the suffix array without the data to w, see
+// LoadSuffixIndex and ReadFrom.
+func (x *SuffixIndex) WriteTo(w io.Writer) (n int64, err error) {
+	buf := make([]byte, binary.MaxVarintLen64, 16<<10)
+	buf = buf[:binary.PutUvarint(buf, uint64(len(x.data)))]
+
+	flush := func() bool {
+		var m int
+		m, err = w.Write(buf)
+		n += int64(m)
+		if err == nil && m != len(buf) {
+			err = io.ErrShortWrite
+		}
+		buf = buf[:0]
+		return err == nil
+	}
+
+	width := x.width()
+	for i, size := 0, x.len(); i < size; i++ {
+		buf = buf[:len(buf)+width]
+		if width == 4 {
+			binary.LittleEndian.PutUint32(buf[len(buf)-width:], uint32(x.sa32[i]))
+		} else {
+			binary.LittleEndian.PutUint64(buf[len(buf)-width:], uint64(x.sa64[i]))
+		}
+		if len(buf)+width > cap(buf) && !flush() {
+			return n, err
+		}
+	}
+	flush()
+	return n, err
+}
+
+// ReadFrom replaces the suffix array of x with the one written by
+// WriteTo for the same data. x is unchanged if an error is returned.
+// ReadFrom modifies x, so it must not be called once x is shared
+// with other goroutines.
+func (x *SuffixIndex) ReadFrom(r io.Reader) (n int64, err error) {
+	var b [1]byte
+	var length uint64
+	for shift := 0; ; shift += 7 {
+		if shift > 63 {
+			return n, ErrVarintOverflow
+		}
+		if _, err = io.ReadFull(r, b[:]); err != nil {
+			return n, unexpectedEOF(err)
+		}
+		n++
+		length |= uint64(b[0]&0x7f) << shift
+		if b[0] < 0x80 {
+			break
+		}
+	}
+	if length != uint64(len(x.data)) {
+		return n, errSuffixIndexData
+	}
+
+	y := SuffixIndex{data: x.data}
+	y.alloc()
+	width := y.width()
+	buf := make([]byte, 16<<10/width*width)
+	for i, size := 0, y.len(); i < size; {
+		chunk := buf
+		if rest := (size - i) * width; rest < len(chunk) {
+			chunk = chunk[:rest]
+		}
+		m, err := io.ReadFull(r, chunk)
+		n += int64(m)
+		if err != nil {
+			return n, unexpectedEOF(err)
+		}
+		for ; len(chunk) > 0; i, chunk = i+1, chunk[width:] {
+			if width == 4 {
+				y.sa32[i] = int32(binary.LittleEndian.Uint32(chunk))
+			} else {
+				y.sa64[i] = int64(binary.LittleEndian.Uint64(chunk))
+			}
+			if v := y.at(i); v < 0 || v >= size {
+				return n, errSuffixIndexData
+			}
+		}
+	}
+	x.sa32, x.sa64 = y.sa32, y.sa64
+	return n, nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (x *SuffixIndex) alloc() {
+	x.sa32, x.sa64 = nil, nil
+	if len(x.data) <= math.MaxInt32 {
+		x.sa32 = make([]int32, len(x.data))
+	} else {
+		x.sa64 = make([]int64, len(x.data))
+	}
+}
+
+func (x *SuffixIndex) len() int { return len(x.data) }
+
+// width returns the size of an element of the serialized suffix array.
+func (x *SuffixIndex) width() int {
+	if x.sa32 != nil || len(x.data) == 0 {
+		return 4
+	}
+	return 8
+}
+
+func (x *SuffixIndex) at(i int) int {
+	if x.sa32 != nil {
+		return int(x.sa32[i])
+	}
+	return int(x.sa64[i])
+}
+
+// lookup returns the range [i, j) of the suffix array with the
+// suffixes starting with pattern.
+func (x *SuffixIndex) lookup(pattern []byte) (i, j int) {
+	suffix := func(k int) []byte { return x.data[x.at(k):] }
+	i = sort.Search(x.len(), func(k int) bool { return bytes.Compare(suffix(k), pattern) >= 0 })
+	j = i + sort.Search(x.len()-i, func(k int) bool { return !bytes.HasPrefix(suffix(i+k), pattern) })
+	return i, j
+}
+
+func pairs(matches [][]int) [][2]int {
+	res := make([][2]int, len(matches))
+	for i, m := range matches {
+		res[i] = [2]int{m[0], m[1]}
+	}
+	return res
+}
+
+// saInt is the type of the elements of a suffix array.
+type saInt interface{ ~int32 | ~int64 }
+
+// saChar is the type of the characters of a text to build a suffix
+// array of: bytes of the data, or names of the reduced texts.
+type saChar interface{ ~byte | ~int32 | ~int64 }
+
+// sais builds the suffix array sa of text over the alphabet [0, k) with
+// the SA-IS algorithm by Nong, Zhang and Chan: the LMS substrings are
+// sorted by induction, named, the reduced text of the names is solved
+// recursively, and its order induces the order of all the suffixes.
+// The text ends with an implicit sentinel less than any character.
+// Besides sa, it uses len(text)/8 bytes for the types and k elements for
+// the buckets. The reduced text and its suffix array share sa.
+func sais[I saInt, C saChar](text []C, sa []I, k int) {
+	n := len(text)
+	if n == 0 {
+		return
+	}
+
+	t := make(saTypes, n/64+1)
+	for i := n - 2; i >= 0; i-- {
+		if text[i] < text[i+1] || text[i] == text[i+1] && t.s(i+1) {
+			t[i/64] |= 1 << (i % 64)
+		}
+	}
+
+	// Sort the LMS substrings.
+	bkt := make([]I, k)
+	for i := range sa {
+		sa[i] = -1
+	}
+	saBuckets(text, bkt, true)
+	for i := 1; i < n; i++ {
+		if t.lms(i) {
+			bkt[text[i]]--
+			sa[bkt[text[i]]] = I(i)
+		}
+	}
+	saInduce(text, sa, bkt, t)
+
+	// Name the sorted LMS substrings. The LMS positions are at least 2
+	// apart, so the names fit into sa[n1:] by the positions halved.
+	n1 := 0
+	for i := 0; i < n; i++ {
+		if t.lms(int(sa[i])) {
+			sa[n1] = sa[i]
+			n1++
+		}
+	}
+	for i := n1; i < n; i++ {
+		sa[i] = -1
+	}
+	names, prev := 0, -1
+	for i := 0; i < n1; i++ {
+		pos := int(sa[i])
+		if prev < 0 || !saEqualLMS(text, t, pos, prev) {
+			names++
+			prev = pos
+		}
+		sa[n1+pos/2] = I(names - 1)
+	}
+
+	// The reduced text of the names in text order takes the end of sa,
+	// its suffix array the beginning.
+	for i, j := n-1, n-1; i >= n1; i-- {
+		if sa[i] >= 0 {
+			sa[j] = sa[i]
+			j--
+		}
+	}
+	text1, sa1 := sa[n-n1:], sa[:n1]
+	if names < n1 {
+		sais[I, I](text1, sa1, names)
+	} else {
+		for i, c := range text1 {
+			sa1[c] = I(i)
+		}
+	}
+
+	// Induce the order of all the suffixes from the sorted LMS suffixes.
+	for i, j := 1, 0; i < n; i++ {
+		if t.lms(i) {
+			text1[j] = I(i)
+			j++
+		}
+	}
+	for i, c := range sa1 {
+		sa1[i] = text1[c]
+	}
+	for i := n1; i < n; i++ {
+		sa[i] = -1
+	}
+	saBuckets(text, bkt, true)
+	for i := n1 - 1; i >= 0; i-- {
+		j := sa[i]
+		sa[i] = -1
+		bkt[text[j]]--
+		sa[bkt[text[j]]] = j
+	}
+	saInduce(text, sa, bkt, t)
+}
+
+// saTypes is a bit set of the S-type suffixes, which are less than the
+// next suffix. The others are L-type, including the last one, which is
+// greater than the sentinel.
+type saTypes []uint64
+
+func (t saTypes) s(i int) bool { return t[i/64]&(1<<(i%64)) != 0 }
+
+// lms reports whether the suffix i is the leftmost S-type suffix of a
+// run.
+func (t saTypes) lms(i int) bool { return i > 0 && t.s(i) && !t.s(i-1) }
+
+// saBuckets sets bkt to the beginnings or the ends of the buckets of
+// the suffixes starting with each character.
+func saBuckets[I saInt, C saChar](text []C, bkt []I, end bool) {
+	for i := range bkt {
+		bkt[i] = 0
+	}
+	for _, c := range text {
+		bkt[c]++
+	}
+	var sum I
+	for i, size := range bkt {
+		sum += size
+		if end {
+			bkt[i] = sum
+		} else {
+			bkt[i] = sum - size
+		}
+	}
+}
+
+// saInduce sorts the L-type suffixes from the sorted LMS suffixes at
+// the ends of their buckets in sa, and then the S-type suffixes from
+// the L-type ones.
+func saInduce[I saInt, C saChar](text []C, sa, bkt []I, t saTypes) {
+	n := len(text)
+	saBuckets(text, bkt, false)
+	// The sentinel is the first suffix, and the one before it is L-type.
+	sa[bkt[text[n-1]]] = I(n - 1)
+	bkt[text[n-1]]++
+	for i := 0; i < n; i++ {
+		if j := int(sa[i]) - 1; j >= 0 && !t.s(j) {
+			sa[bkt[text[j]]] = I(j)
+			bkt[text[j]]++
+		}
+	}
+
+	saBuckets(text, bkt, true)
+	for i := n - 1; i >= 0; i-- {
+		if j := int(sa[i]) - 1; j >= 0 && t.s(j) {
+			bkt[text[j]]--
+			sa[bkt[text[j]]] = I(j)
+		}
+	}
+}
+
+// saEqualLMS reports whether the LMS substrings starting with a and b
+// are equal. The last one includes the sentinel, so it's unique.
+func saEqualLMS[C saChar](text []C, t saTypes, a, b int) bool {
+	for d := 0; ; d++ {
+		if a+d == len(text) || b+d == len(text) || text[a+d] != text[b+d] || t.s(a+d) != t.s(b+d) {
+			return false
+		}
+		if d > 0 && (t.lms(a+d) || t.lms(b+d)) {
+			return true
+		}
+	}
+}
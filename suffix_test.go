@@ -0,0 +1,208 @@
+package readonly_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"index/suffixarray"
+	"io"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleSuffixIndex() {
+	x := readonly.NewSuffixIndex(readonly.NewByteSlice("banana bandana"))
+
+	offsets := x.Lookup(readonly.NewByteSlice("ana"), -1).Copy()
+	sort.Ints(offsets)
+	fmt.Println(offsets, x.Count(readonly.NewByteSlice("an")))
+	fmt.Println(x.FindAllRegexp(regexp.MustCompile(`ban?d?a`), -1).Copy())
+	// Output:
+	// [1 3 11] 4
+	// [[0 4] [7 12]]
+}
+
+func TestSuffixIndex(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, data := range []string{"", "a", "aaaaaaaa", "mississippi", randomText(rnd, "ab", 1000), randomText(rnd, "abc\n", 10000)} {
+		x := readonly.NewSuffixIndex(readonly.NewByteSlice(data))
+		expected := suffixarray.New([]byte(data))
+
+		for _, pattern := range []string{"", "a", "aa", "ab", "ssi", "abca", "b\nc", "x", data} {
+			offsets := x.Lookup(readonly.NewByteSlice(pattern), -1).Copy()
+			expectedOffsets := expected.Lookup([]byte(pattern), -1)
+			sort.Ints(offsets)
+			sort.Ints(expectedOffsets)
+			if fmt.Sprint(offsets) != fmt.Sprint(expectedOffsets) {
+				t.Fatalf("%.10q: %q: expected %v, got %v", data, pattern, expectedOffsets, offsets)
+			}
+			if count := x.Count(readonly.NewByteSlice(pattern)); count != len(expectedOffsets) {
+				t.Fatalf("%.10q: %q: expected count %d, got %d", data, pattern, len(expectedOffsets), count)
+			}
+			if n := x.Lookup(readonly.NewByteSlice(pattern), 2).Len(); n > 2 || n < len(expectedOffsets) && n < 2 {
+				t.Fatalf("%.10q: %q: unexpected number of limited matches %d", data, pattern, n)
+			}
+		}
+
+		for _, expr := range []string{"a", "ab+", "a(b|c)a", "[ab]c", "ss?i", "^a", "(?i)A"} {
+			r := regexp.MustCompile(expr)
+			for _, n := range []int{-1, 0, 1, 5} {
+				matches := x.FindAllRegexp(r, n)
+				if fmt.Sprint(matches.Copy()) != fmt.Sprint(pairs(expected.FindAllIndex(r, n))) {
+					t.Fatalf("%.10q: %q/%d: expected %v, got %v", data, expr, n, expected.FindAllIndex(r, n), matches.Copy())
+				}
+			}
+		}
+
+		var buf bytes.Buffer
+		n, err := x.WriteTo(&buf)
+		if err != nil || n != int64(buf.Len()) {
+			t.Fatalf("%.10q: unexpected WriteTo result %d %v", data, n, err)
+		}
+		serialized := buf.String()
+		loaded, err := readonly.LoadSuffixIndex(readonly.NewByteSlice(data), &buf)
+		if err != nil || loaded.Count(readonly.NewByteSlice("a")) != x.Count(readonly.NewByteSlice("a")) {
+			t.Fatalf("%.10q: unexpected loaded index %v", data, err)
+		}
+		if n, err = loaded.ReadFrom(strings.NewReader(serialized)); err != nil || n != int64(len(serialized)) {
+			t.Fatalf("%.10q: unexpected ReadFrom result %d %v", data, n, err)
+		}
+		if _, err = loaded.ReadFrom(strings.NewReader(serialized[:len(serialized)-1])); err == nil ||
+			loaded.Count(readonly.NewByteSlice("a")) != x.Count(readonly.NewByteSlice("a")) {
+			t.Fatalf("%.10q: failed ReadFrom must keep the index, got %v", data, err)
+		}
+
+		if len(data) > 0 {
+			for _, c := range []struct {
+				data, serialized string
+				err              error
+			}{
+				{data[1:], serialized, nil},
+				{data, serialized[:len(serialized)-1], io.ErrUnexpectedEOF},
+				{data, serialized[:0], io.ErrUnexpectedEOF},
+			} {
+				_, err = readonly.LoadSuffixIndex(readonly.NewByteSlice(c.data), strings.NewReader(c.serialized))
+				if err == nil || c.err != nil && !errors.Is(err, c.err) {
+					t.Fatalf("%.10q: expected error %v, got %v", data, c.err, err)
+				}
+			}
+		}
+	}
+}
+
+func TestNewSuffixIndex(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		alphabet := []string{"a", "ab", "abc", "abcdefghijklmnopqrstuvwxyz", "\x00\xff"}[i%5]
+		data := randomText(rnd, alphabet, rnd.Intn(300))
+		if i%7 == 0 {
+			data = strings.Repeat(data[:len(data)/4], 4) // repeated LMS substrings.
+		}
+
+		expected := make([]int, len(data))
+		for j := range expected {
+			expected[j] = j
+		}
+		sort.Slice(expected, func(a, b int) bool { return data[expected[a]:] < data[expected[b]:] })
+
+		// The serialized index is the suffix array after the length.
+		var buf bytes.Buffer
+		if _, err := readonly.NewSuffixIndex(readonly.NewByteSlice(data)).WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		b := buf.Bytes()
+		if _, k := binary.Uvarint(b); k > 0 {
+			b = b[k:]
+		}
+		actual := make([]int, 0, len(b)/4)
+		for ; len(b) >= 4; b = b[4:] {
+			actual = append(actual, int(binary.LittleEndian.Uint32(b)))
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Fatalf("%q: expected %v, got %v", data, expected, actual)
+		}
+	}
+}
+
+func TestSuffixIndex_FindAllRegexp(t *testing.T) {
+	data := strings.Repeat("abc ab abcd ", 10)
+	x := readonly.NewSuffixIndex(readonly.NewByteSlice(data))
+
+	longest := regexp.MustCompile(`ab|abcd?`)
+	longest.Longest()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(r *regexp.Regexp) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				expected := pairs(r.FindAllIndex([]byte(data), -1))
+				if actual := x.FindAllRegexp(r, -1).Copy(); fmt.Sprint(actual) != fmt.Sprint(expected) {
+					t.Errorf("%v: expected %v, got %v", r, expected, actual)
+					return
+				}
+			}
+		}([]*regexp.Regexp{regexp.MustCompile(`ab|abcd?`), longest}[i%2])
+	}
+	wg.Wait()
+}
+
+func randomText(rnd *rand.Rand, alphabet string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[rnd.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+func pairs(matches [][]int) [][2]int {
+	res := make([][2]int, 0, len(matches))
+	for _, m := range matches {
+		res = append(res, [2]int{m[0], m[1]})
+	}
+	return res
+}
+
+func BenchmarkSuffixIndex_Lookup(b *testing.B) {
+	data := randomText(rand.New(rand.NewSource(1)), "abcdefgh ", limit*10)
+	pattern := data[limit : limit+8]
+	x := readonly.NewSuffixIndex(readonly.NewByteSlice(data))
+
+	b.Run("SuffixIndex", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Count(readonly.NewByteSlice(pattern))
+		}
+	})
+	b.Run("strings.Count", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			strings.Count(data, pattern)
+		}
+	})
+}
+
+func BenchmarkNewSuffixIndex(b *testing.B) {
+	data := []byte(randomText(rand.New(rand.NewSource(1)), "abcdefgh ", limit*10))
+
+	b.Run("NewSuffixIndex", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			readonly.NewSuffixIndex(readonly.NewByteSlice(data))
+		}
+	})
+	b.Run("suffixarray.New", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			suffixarray.New(data)
+		}
+	})
+}
@@ -0,0 +1,327 @@
+package readonly
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Ordered is a constraint that permits any type supporting the <
+// operator.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Column is a named column of a Table, see NewColumn and
+// NewColumnFunc.
+type Column interface {
+	// Name returns the name of the column.
+	Name() string
+
+	// Len returns the number of values.
+	Len() int
+
+	// Get returns the value at index.
+	Get(index int) any
+
+	less(i, j int) bool
+	sortable() bool
+}
+
+// NewColumn returns a column with the values of s, which a Table can
+// be sorted by.
+func NewColumn[T Ordered](name string, s Slice[T]) Column {
+	return column[T]{name, s.s, func(a, b T) bool { return a < b }}
+}
+
+// NewColumnFunc returns a column with the values of s, ordered by
+// less. If less == nil, a Table can't be sorted by the column.
+func NewColumnFunc[T any](name string, s Slice[T], less func(a, b T) bool) Column {
+	return column[T]{name, s.s, less}
+}
+
+type column[T any] struct {
+	name string
+	s    []T
+	lt   func(a, b T) bool
+}
+
+func (c column[T]) Name() string       { return c.name }
+func (c column[T]) Len() int           { return len(c.s) }
+func (c column[T]) Get(index int) any  { return c.s[index] }
+func (c column[T]) less(i, j int) bool { return c.lt(c.s[i], c.s[j]) }
+func (c column[T]) sortable() bool     { return c.lt != nil }
+
+var errColumnLength = errors.New("readonly.NewTable: columns of different lengths")
+
+// NewTable returns a read-only table of columns of equal length.
+// Filter, SortedBy and Project return views of the table that share
+// the storage of the columns.
+func NewTable(cols ...Column) (Table, error) {
+	t := Table{cols: cols, names: make(map[string]int, len(cols))}
+	for i, c := range cols {
+		if _, ok := t.names[c.Name()]; ok {
+			return Table{}, fmt.Errorf("readonly.NewTable: duplicate column %q", c.Name())
+		}
+		if i > 0 && c.Len() != t.n {
+			return Table{}, errColumnLength
+		}
+		t.names[c.Name()], t.n = i, c.Len()
+	}
+	return t, nil
+}
+
+// Table is a set of named columns of equal length.
+type Table struct {
+	cols  []Column
+	names map[string]int
+	index []int // selected rows of the columns, nil means all of them.
+	n     int
+}
+
+// Len returns the number of rows.
+func (t Table) Len() int { return t.n }
+
+// Columns returns the names of the columns.
+func (t Table) Columns() Slice[string] {
+	names := make([]string, len(t.cols))
+	for i, c := range t.cols {
+		names[i] = c.Name()
+	}
+	return Slice[string]{names}
+}
+
+// Row returns the row i.
+// Panics if i is out of range.
+func (t Table) Row(i int) Row {
+	if i < 0 || i >= t.n {
+		panic("readonly.Table: row index out of range")
+	}
+	return Row{t, t.storage(i)}
+}
+
+// Range calls f for every row in order.
+// Does nothing if f == nil.
+// Breaks the loop if next == false.
+func (t Table) Range(f func(index int, row Row) (next bool)) {
+	if f != nil {
+		for i := 0; i < t.n; i++ {
+			if !f(i, Row{t, t.storage(i)}) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns a view of the rows for which f returns true.
+func (t Table) Filter(f func(row Row) bool) Table {
+	index := make([]int, 0)
+	t.Range(func(_ int, row Row) bool {
+		if f(row) {
+			index = append(index, row.i)
+		}
+		return true
+	})
+	t.index, t.n = index, len(index)
+	return t
+}
+
+// SortedBy returns a view of the rows stably sorted by the column.
+// Panics if there is no such column or it's not sortable.
+func (t Table) SortedBy(name string) Table {
+	c := t.cols[t.column(name)]
+	if !c.sortable() {
+		panic("readonly.Table.SortedBy: column " + name + " is not sortable")
+	}
+
+	index := make([]int, t.n)
+	for i := range index {
+		index[i] = t.storage(i)
+	}
+	sort.SliceStable(index, func(i, j int) bool { return c.less(index[i], index[j]) })
+	t.index = index
+	return t
+}
+
+// Project returns a view of the table with only the columns with the
+// names in the given order.
+// Panics if there is no such column or a name is repeated.
+func (t Table) Project(names ...string) Table {
+	cols := make([]Column, len(names))
+	for i, name := range names {
+		cols[i] = t.cols[t.column(name)]
+	}
+	p, err := NewTable(cols...)
+	if err != nil {
+		panic(err)
+	}
+	p.index, p.n = t.index, t.n
+	return p
+}
+
+// ColumnOf returns a view of the values of the column in the order of
+// the rows of t. If t is not a view made by Filter or SortedBy, the
+// view is a Slice[T].
+// ok is false if there is no such column or it's not of type T.
+func ColumnOf[T any](t Table, name string) (v View[T], ok bool) {
+	i, ok := t.names[name]
+	if !ok {
+		return nil, false
+	}
+	c, ok := t.cols[i].(column[T])
+	if !ok {
+		return nil, false
+	}
+	if t.index == nil {
+		return Slice[T]{c.s[:len(c.s):len(c.s)]}, true
+	}
+	return indexView[T]{c.s, t.index}, true
+}
+
+type indexView[T any] struct {
+	s     []T
+	index []int
+}
+
+func (v indexView[T]) Len() int        { return len(v.index) }
+func (v indexView[T]) Get(index int) T { return v.s[v.index[index]] }
+
+func (v indexView[T]) Range(f func(index int, val T) (next bool)) {
+	if f != nil {
+		for i, j := range v.index {
+			if !f(i, v.s[j]) {
+				return
+			}
+		}
+	}
+}
+
+// CSV returns a writer of the table in CSV format with a header.
+// Values are formatted with fmt.Sprint.
+func (t Table) CSV() io.WriterTo { return tableCSV{t} }
+
+// JSON returns a writer of the table as a JSON array of objects.
+// Values are encoded with encoding/json, ByteSlice values as strings.
+func (t Table) JSON() io.WriterTo { return tableJSON{t} }
+
+func (t Table) storage(i int) int {
+	if t.index == nil {
+		return i
+	}
+	return t.index[i]
+}
+
+func (t Table) column(name string) int {
+	i, ok := t.names[name]
+	if !ok {
+		panic("readonly.Table: no column " + name)
+	}
+	return i
+}
+
+// Row is a row of a Table.
+type Row struct {
+	t Table
+	i int
+}
+
+// Get returns the value of the column.
+// Panics if there is no such column.
+func (r Row) Get(name string) any { return r.t.cols[r.t.column(name)].Get(r.i) }
+
+// Field returns the value of the column of the row.
+// Panics if there is no such column or it's not of type T.
+func Field[T any](r Row, name string) T { return r.t.cols[r.t.column(name)].(column[T]).s[r.i] }
+
+type tableCSV struct{ t Table }
+
+func (c tableCSV) WriteTo(w io.Writer) (n int64, err error) {
+	cw := &countingWriter{w: w}
+	csvw := csv.NewWriter(cw)
+	record := c.t.Columns().Copy()
+	if err = csvw.Write(record); err != nil {
+		return cw.n, err
+	}
+
+	c.t.Range(func(_ int, row Row) bool {
+		for i, col := range c.t.cols {
+			switch v := col.Get(row.i).(type) {
+			case string:
+				record[i] = v
+			default:
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		err = csvw.Write(record)
+		return err == nil
+	})
+	if err != nil {
+		return cw.n, err
+	}
+
+	csvw.Flush()
+	return cw.n, csvw.Error()
+}
+
+type tableJSON struct{ t Table }
+
+func (j tableJSON) WriteTo(w io.Writer) (n int64, err error) {
+	keys := make([][]byte, len(j.t.cols))
+	for i, col := range j.t.cols {
+		if keys[i], err = json.Marshal(col.Name()); err != nil {
+			return 0, err
+		}
+	}
+
+	cw := &countingWriter{w: w}
+	buf := []byte{'['}
+	j.t.Range(func(index int, row Row) bool {
+		if index > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, '{')
+		for i, col := range j.t.cols {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(append(buf, keys[i]...), ':')
+
+			v := col.Get(row.i)
+			if b, ok := v.(ByteSlice); ok {
+				v = b.String()
+			}
+			var value []byte
+			if value, err = json.Marshal(v); err != nil {
+				return false
+			}
+			buf = append(buf, value...)
+		}
+		buf = append(buf, '}')
+
+		_, err = cw.Write(buf)
+		buf = buf[:0]
+		return err == nil
+	})
+	if err != nil {
+		return cw.n, err
+	}
+
+	_, err = cw.Write(append(buf, ']'))
+	return cw.n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (n int, err error) {
+	n, err = c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
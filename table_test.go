@@ -0,0 +1,141 @@
+package readonly_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func newTable(t testing.TB) readonly.Table {
+	table, err := readonly.NewTable(
+		readonly.NewColumn("name", readonly.NewSlice([]string{"carol", "alice", "bob", "dave"})),
+		readonly.NewColumn("age", readonly.NewSlice([]int{35, 30, 25, 30})),
+		readonly.NewColumnFunc("note", readonly.NewSlice([]readonly.ByteSlice{
+			readonly.NewByteSlice("x"), readonly.NewByteSlice(`"quoted"`), readonly.NewByteSlice("a,b"), {},
+		}), nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func ExampleTable() {
+	table, _ := readonly.NewTable(
+		readonly.NewColumn("name", readonly.NewSlice([]string{"carol", "alice", "bob"})),
+		readonly.NewColumn("age", readonly.NewSlice([]int{35, 30, 25})),
+	)
+
+	adults := table.
+		Filter(func(row readonly.Row) bool { return readonly.Field[int](row, "age") >= 30 }).
+		SortedBy("name")
+	_, _ = adults.CSV().WriteTo(os.Stdout)
+	_, _ = adults.Project("age").JSON().WriteTo(os.Stdout)
+	// Output:
+	// name,age
+	// alice,30
+	// carol,35
+	// [{"age":30},{"age":35}]
+}
+
+func TestNewTable(t *testing.T) {
+	a := readonly.NewColumn("a", readonly.NewSlice([]int{1, 2}))
+	for _, cols := range [][]readonly.Column{
+		{a, readonly.NewColumn("b", readonly.NewSlice([]int{1}))},
+		{a, readonly.NewColumn("a", readonly.NewSlice([]int{1, 2}))},
+	} {
+		if _, err := readonly.NewTable(cols...); err == nil {
+			t.Fatalf("expected error for %v", cols)
+		}
+	}
+
+	table, err := readonly.NewTable()
+	if err != nil || table.Len() != 0 || table.Columns().Len() != 0 {
+		t.Fatalf("unexpected empty table %v %v", table, err)
+	}
+}
+
+func TestTable(t *testing.T) {
+	table := newTable(t)
+
+	sorted := table.SortedBy("age")
+	var names []string
+	sorted.Range(func(_ int, row readonly.Row) bool {
+		names = append(names, row.Get("name").(string))
+		return true
+	})
+	if fmt.Sprint(names) != "[bob alice dave carol]" {
+		t.Fatalf("expected a stable sort, got %v", names)
+	}
+
+	filtered := sorted.Filter(func(row readonly.Row) bool { return readonly.Field[int](row, "age") == 30 })
+	ages, ok := readonly.ColumnOf[int](filtered, "age")
+	if !ok || filtered.Len() != 2 || ages.Len() != 2 || filtered.Row(1).Get("name") != "dave" {
+		t.Fatalf("unexpected filtered table %v", readonly.Materialize(ages).Copy())
+	}
+
+	if _, ok := readonly.ColumnOf[string](table, "age"); ok {
+		t.Fatal("expected the type mismatch")
+	}
+	if _, ok := readonly.ColumnOf[string](table, "unknown"); ok {
+		t.Fatal("expected no column")
+	}
+	if v, _ := readonly.ColumnOf[string](table, "name"); v.(readonly.Slice[string]).Cap() != 4 {
+		t.Fatal("unfiltered column must be a Slice limited to its length")
+	}
+
+	projected := filtered.Project("note", "name")
+	if fmt.Sprint(projected.Columns().Copy()) != "[note name]" || projected.Len() != 2 {
+		t.Fatalf("unexpected projection %v", projected.Columns().Copy())
+	}
+
+	mustPanic(t, func() { table.Row(4) })
+	mustPanic(t, func() { table.SortedBy("note") })
+	mustPanic(t, func() { table.SortedBy("unknown") })
+	mustPanic(t, func() { table.Project("name", "name") })
+	mustPanic(t, func() { readonly.Field[string](table.Row(0), "age") })
+}
+
+func TestTable_WriteTo(t *testing.T) {
+	table := newTable(t)
+
+	var buf bytes.Buffer
+	n, err := table.CSV().WriteTo(&buf)
+	expected := "name,age,note\ncarol,35,x\nalice,30,\"\"\"quoted\"\"\"\nbob,25,\"a,b\"\ndave,30,\n"
+	if err != nil || n != int64(buf.Len()) || buf.String() != expected {
+		t.Fatalf("unexpected CSV (%d, %v):\n%s", n, err, buf.String())
+	}
+
+	buf.Reset()
+	n, err = table.JSON().WriteTo(&buf)
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("unexpected JSON (%d, %v): %s", n, err, buf.String())
+	}
+	var rows []struct {
+		Name string
+		Age  int
+		Note string
+	}
+	if err = json.Unmarshal(buf.Bytes(), &rows); err != nil || len(rows) != 4 || rows[1].Note != `"quoted"` {
+		t.Fatalf("unexpected JSON %s: %v", buf.String(), err)
+	}
+
+	empty := table.Filter(func(readonly.Row) bool { return false })
+	if _, err = empty.JSON().WriteTo(&buf); err != nil || !strings.HasSuffix(buf.String(), "[]") {
+		t.Fatalf("unexpected JSON of empty table %s: %v", buf.String(), err)
+	}
+
+	failing := writer(func([]byte) (int, error) { return 0, io.ErrClosedPipe })
+	for _, w := range []io.WriterTo{table.CSV(), table.JSON()} {
+		if _, err = w.WriteTo(failing); !errors.Is(err, io.ErrClosedPipe) {
+			t.Fatalf("expected %v, got %v", io.ErrClosedPipe, err)
+		}
+	}
+}
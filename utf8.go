@@ -0,0 +1,97 @@
+package readonly
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// ValidUTF8 equivalent to utf8.Valid(b).
+func (b ByteSlice) ValidUTF8() bool { return utf8.Valid(b.s) }
+
+// RuneCount equivalent to utf8.RuneCount(b).
+func (b ByteSlice) RuneCount() int { return utf8.RuneCount(b.s) }
+
+// Runes equivalent to read-only for range loop over string(b).
+// off is the byte offset of r, invalid bytes are reported as
+// utf8.RuneError of size 1.
+// Does nothing if f == nil.
+// Breaks the loop if next == false.
+func (b ByteSlice) Runes(f func(off int, r rune) (next bool)) {
+	if f != nil {
+		for off, r := range b.String() {
+			if !f(off, r) {
+				return
+			}
+		}
+	}
+}
+
+// DecodeRuneAt equivalent to utf8.DecodeRune(b[off:]).
+func (b ByteSlice) DecodeRuneAt(off int) (r rune, size int) {
+	if off < len(b.s) && b.s[off] < utf8.RuneSelf {
+		return rune(b.s[off]), 1
+	}
+	return utf8.DecodeRune(b.s[off:])
+}
+
+// DecodeLastRune equivalent to utf8.DecodeLastRune(b).
+func (b ByteSlice) DecodeLastRune() (r rune, size int) { return utf8.DecodeLastRune(b.s) }
+
+// ToValidUTF8 equivalent to bytes.ToValidUTF8(b, replacement), but
+// returns b itself without copying if it is already valid UTF-8.
+func (b ByteSlice) ToValidUTF8(replacement string) ByteSlice {
+	if utf8.Valid(b.s) {
+		return b
+	}
+	return NewByteSlice(bytes.ToValidUTF8(b.s, []byte(replacement)))
+}
+
+// utf8IndexStep is the number of runes between the offsets stored
+// by UTF8Index.
+const utf8IndexStep = 64
+
+// NewUTF8Index builds a UTF8Index for b in a single pass.
+func NewUTF8Index(b ByteSlice) *UTF8Index {
+	x := &UTF8Index{b: b}
+	x.offsets = make([]int, 0, len(b.s)/utf8IndexStep+1)
+	for off := range b.String() {
+		if x.count%utf8IndexStep == 0 {
+			x.offsets = append(x.offsets, off)
+		}
+		x.count++
+	}
+	return x
+}
+
+// UTF8Index is a sparse table of rune offsets, which allows random
+// access to the runes of a large read-only text.
+// Each lookup decodes at most 64 runes.
+// Invalid bytes are counted as utf8.RuneError of size 1, like in a
+// for range loop.
+type UTF8Index struct {
+	b       ByteSlice
+	offsets []int // offsets[i] is the byte offset of rune i*utf8IndexStep.
+	count   int
+}
+
+// RuneCount returns the number of runes in the text.
+func (x *UTF8Index) RuneCount() int { return x.count }
+
+// RuneOffset returns the byte offset of the i-th rune.
+// Panics if i is out of range.
+func (x *UTF8Index) RuneOffset(i int) int {
+	if i < 0 || i >= x.count {
+		panic("readonly.UTF8Index: rune index out of range")
+	}
+
+	off := x.offsets[i/utf8IndexStep]
+	for n := i % utf8IndexStep; n > 0; n-- {
+		_, size := x.b.DecodeRuneAt(off)
+		off += size
+	}
+	return off
+}
+
+// RuneAt returns the i-th rune and its size in bytes.
+// Panics if i is out of range.
+func (x *UTF8Index) RuneAt(i int) (r rune, size int) { return x.b.DecodeRuneAt(x.RuneOffset(i)) }
@@ -0,0 +1,100 @@
+package readonly_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleByteSlice_Runes() {
+	readonly.NewByteSlice("aф\xffb").Runes(func(off int, r rune) (next bool) {
+		fmt.Printf("%d %q\n", off, r)
+		return r != utf8.RuneError
+	})
+	// Output:
+	// 0 'a'
+	// 1 'ф'
+	// 3 '�'
+}
+
+func ExampleByteSlice_DecodeRuneAt() {
+	b := readonly.NewByteSlice("aфb")
+
+	fmt.Println(b.DecodeRuneAt(1))
+	fmt.Println(b.DecodeLastRune())
+	fmt.Println(b.RuneCount(), b.ValidUTF8())
+	// Output:
+	// 1092 2
+	// 98 1
+	// 3 true
+}
+
+func ExampleByteSlice_ToValidUTF8() {
+	fmt.Println(readonly.NewByteSlice("a\xff\xfeb").ToValidUTF8("?"))
+	// Output:
+	// a?b
+}
+
+func ExampleUTF8Index() {
+	x := readonly.NewUTF8Index(readonly.NewByteSlice(strings.Repeat("фыва", 100)))
+
+	r, size := x.RuneAt(201)
+	fmt.Println(x.RuneCount(), x.RuneOffset(201), string(r), size)
+	// Output:
+	// 400 402 ы 2
+}
+
+func TestByteSlice_ToValidUTF8(t *testing.T) {
+	b := readonly.NewByteSlice("valid")
+	if v := b.ToValidUTF8("?"); dataPointer(v) != dataPointer(b) {
+		t.Fatal("expected valid UTF-8 not to be copied")
+	}
+}
+
+func TestUTF8Index(t *testing.T) {
+	for i, s := range []string{
+		"",
+		"ascii only",
+		strings.Repeat("aф\xff😀", 100),
+		strings.Repeat("ф", 64),
+		strings.Repeat("ф", 65),
+	} {
+		x := readonly.NewUTF8Index(readonly.NewByteSlice(s))
+		if x.RuneCount() != utf8.RuneCountInString(s) {
+			t.Fatalf("[%d] expected %d runes, got %d", i, utf8.RuneCountInString(s), x.RuneCount())
+		}
+
+		var n int
+		for off, r := range s {
+			actual, size := x.RuneAt(n)
+			if x.RuneOffset(n) != off || actual != r || size == 0 {
+				t.Fatalf("[%d] rune %d: expected %q at %d, got %q at %d", i, n, r, off, actual, x.RuneOffset(n))
+			}
+			n++
+		}
+	}
+
+	x := readonly.NewUTF8Index(readonly.NewByteSlice("abc"))
+	mustPanic(t, func() { x.RuneAt(-1) })
+	mustPanic(t, func() { x.RuneAt(3) })
+}
+
+func BenchmarkUTF8Index_RuneAt(b *testing.B) {
+	text := readonly.NewByteSlice(strings.Repeat("ascii и не только ", limit/10))
+	x := readonly.NewUTF8Index(text)
+	n := x.RuneCount()
+
+	b.Run("readonly.UTF8Index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.RuneAt(i * 7919 % n)
+		}
+	})
+	b.Run("[]rune", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = []rune(text.String())[i*7919%n]
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package readonly
+
+// View is a read-only sequence of elements.
+// Slice implements View, as do the lazy views returned by Mapped,
+// Concat, Reversed, Strided and Zip, which compute their elements on
+// access instead of copying them. The lazy views accept any View and
+// keep it as is, so they can be composed without allocations.
+// Their element types are the first type parameters, so they can be
+// given explicitly where they can't be inferred, e.g.
+// Concat[int](s, Mapped(s, f)).
+type View[T any] interface {
+	// Len returns the number of elements.
+	Len() int
+
+	// Get returns the element at index.
+	// Panics if index is out of range.
+	Get(index int) T
+
+	// Range calls f for every element in order.
+	// Does nothing if f == nil.
+	// Breaks the loop if next == false.
+	Range(f func(index int, val T) (next bool))
+}
+
+// Materialize copies the elements of v into a new Slice.
+func Materialize[T any](v View[T]) Slice[T] {
+	s := make([]T, 0, v.Len())
+	v.Range(func(_ int, val T) bool { s = append(s, val); return true })
+	return Slice[T]{s}
+}
+
+// Mapped returns a view of f applied to the elements of v.
+// f is called on every access, so it should be cheap and pure.
+func Mapped[T, U any, V View[T]](v V, f func(T) U) MappedView[T, U, V] {
+	return MappedView[T, U, V]{v, f}
+}
+
+// MappedView is a view returned by Mapped.
+type MappedView[T, U any, V View[T]] struct {
+	v V
+	f func(T) U
+}
+
+// Len returns the number of elements.
+func (v MappedView[T, U, V]) Len() int { return v.v.Len() }
+
+// Get returns the element at index.
+// Panics if index is out of range.
+func (v MappedView[T, U, V]) Get(index int) U { return v.f(v.v.Get(index)) }
+
+// Range calls f for every element in order.
+// Does nothing if f == nil.
+// Breaks the loop if next == false.
+func (v MappedView[T, U, V]) Range(f func(index int, val U) (next bool)) {
+	if f != nil {
+		for i, n := 0, v.v.Len(); i < n; i++ {
+			if !f(i, v.f(v.v.Get(i))) {
+				return
+			}
+		}
+	}
+}
+
+// Concat returns a view of the elements of a followed by the elements
+// of b.
+func Concat[T any, A View[T], B View[T]](a A, b B) ConcatView[T, A, B] {
+	return ConcatView[T, A, B]{a, b}
+}
+
+// ConcatView is a view returned by Concat.
+type ConcatView[T any, A View[T], B View[T]] struct {
+	a A
+	b B
+}
+
+// Len returns the number of elements.
+func (v ConcatView[T, A, B]) Len() int { return v.a.Len() + v.b.Len() }
+
+// Get returns the element at index.
+// Panics if index is out of range.
+func (v ConcatView[T, A, B]) Get(index int) T {
+	if n := v.a.Len(); index >= n {
+		return v.b.Get(index - n)
+	}
+	return v.a.Get(index)
+}
+
+// Range calls f for every element in order.
+// Does nothing if f == nil.
+// Breaks the loop if next == false.
+func (v ConcatView[T, A, B]) Range(f func(index int, val T) (next bool)) {
+	if f != nil {
+		n := v.a.Len()
+		for i := 0; i < n; i++ {
+			if !f(i, v.a.Get(i)) {
+				return
+			}
+		}
+		for i, m := 0, v.b.Len(); i < m; i++ {
+			if !f(n+i, v.b.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Reversed returns a view of the elements of v in reverse order.
+func Reversed[T any, V View[T]](v V) ReversedView[T, V] { return ReversedView[T, V]{v} }
+
+// ReversedView is a view returned by Reversed.
+type ReversedView[T any, V View[T]] struct{ v V }
+
+// Len returns the number of elements.
+func (v ReversedView[T, V]) Len() int { return v.v.Len() }
+
+// Get returns the element at index.
+// Panics if index is out of range.
+func (v ReversedView[T, V]) Get(index int) T {
+	n := v.v.Len()
+	if index < 0 || index >= n {
+		panic("readonly.Reversed: index out of range")
+	}
+	return v.v.Get(n - 1 - index)
+}
+
+// Range calls f for every element in order.
+// Does nothing if f == nil.
+// Breaks the loop if next == false.
+func (v ReversedView[T, V]) Range(f func(index int, val T) (next bool)) {
+	if f != nil {
+		for i, n := 0, v.v.Len(); i < n; i++ {
+			if !f(i, v.v.Get(n-1-i)) {
+				return
+			}
+		}
+	}
+}
+
+// Strided returns a view of every step-th element of v, starting with
+// the first one.
+// Panics if step <= 0.
+func Strided[T any, V View[T]](v V, step int) StridedView[T, V] {
+	if step <= 0 {
+		panic("readonly.Strided: non-positive step")
+	}
+	return StridedView[T, V]{v, step}
+}
+
+// StridedView is a view returned by Strided.
+type StridedView[T any, V View[T]] struct {
+	v    V
+	step int
+}
+
+// Len returns the number of elements.
+func (v StridedView[T, V]) Len() int {
+	if v.step == 0 {
+		return 0
+	}
+	return (v.v.Len() + v.step - 1) / v.step
+}
+
+// Get returns the element at index.
+// Panics if index is out of range.
+func (v StridedView[T, V]) Get(index int) T {
+	if index < 0 || index >= v.Len() {
+		panic("readonly.Strided: index out of range")
+	}
+	return v.v.Get(index * v.step)
+}
+
+// Range calls f for every element in order.
+// Does nothing if f == nil.
+// Breaks the loop if next == false.
+func (v StridedView[T, V]) Range(f func(index int, val T) (next bool)) {
+	if f != nil {
+		for i, j, n := 0, 0, v.v.Len(); j < n; i, j = i+1, j+v.step {
+			if !f(i, v.v.Get(j)) {
+				return
+			}
+		}
+	}
+}
+
+// Pair is an element of a view returned by Zip.
+type Pair[T, U any] struct {
+	First  T
+	Second U
+}
+
+// Zip returns a view of pairs of the elements of a and b with the same
+// index. The view is as long as the shorter of a and b.
+func Zip[T, U any, A View[T], B View[U]](a A, b B) ZipView[T, U, A, B] {
+	return ZipView[T, U, A, B]{a, b}
+}
+
+// ZipView is a view returned by Zip.
+type ZipView[T, U any, A View[T], B View[U]] struct {
+	a A
+	b B
+}
+
+// Len returns the number of elements.
+func (v ZipView[T, U, A, B]) Len() int {
+	if n := v.a.Len(); n < v.b.Len() {
+		return n
+	}
+	return v.b.Len()
+}
+
+// Get returns the element at index.
+// Panics if index is out of range.
+func (v ZipView[T, U, A, B]) Get(index int) Pair[T, U] {
+	if index < 0 || index >= v.Len() {
+		panic("readonly.Zip: index out of range")
+	}
+	return Pair[T, U]{v.a.Get(index), v.b.Get(index)}
+}
+
+// Range calls f for every element in order.
+// Does nothing if f == nil.
+// Breaks the loop if next == false.
+func (v ZipView[T, U, A, B]) Range(f func(index int, val Pair[T, U]) (next bool)) {
+	if f != nil {
+		for i, n := 0, v.Len(); i < n; i++ {
+			if !f(i, Pair[T, U]{v.a.Get(i), v.b.Get(i)}) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package readonly_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/psyhatter/readonly"
+)
+
+func ExampleMapped() {
+	s := readonly.NewSlice([]int{1, 2, 3})
+
+	v := readonly.Mapped(s, func(i int) string { return strconv.Itoa(i * 10) })
+	fmt.Println(v.Len(), v.Get(1))
+	fmt.Println(readonly.Materialize[string](v).Copy())
+	// Output:
+	// 3 20
+	// [10 20 30]
+}
+
+func ExampleZip() {
+	names := readonly.NewSlice([]string{"a", "b", "c"})
+	values := readonly.NewSlice([]int{1, 2})
+
+	readonly.Zip[string, int](names, values).Range(func(i int, p readonly.Pair[string, int]) bool {
+		fmt.Println(i, p.First, p.Second)
+		return true
+	})
+	// Output:
+	// 0 a 1
+	// 1 b 2
+}
+
+func TestViews(t *testing.T) {
+	a, b := readonly.NewSlice([]int{0, 1, 2, 3, 4}), readonly.NewSlice([]int{5, 6})
+
+	for name, c := range map[string]struct {
+		v        readonly.View[int]
+		expected []int
+	}{
+		"Slice":      {a, []int{0, 1, 2, 3, 4}},
+		"Mapped":     {readonly.Mapped(a, neg), []int{0, -1, -2, -3, -4}},
+		"Concat":     {readonly.Concat[int](a, b), []int{0, 1, 2, 3, 4, 5, 6}},
+		"Concat nil": {readonly.Concat[int](readonly.Slice[int]{}, b), []int{5, 6}},
+		"Concat any": {readonly.Concat[int](b, readonly.Mapped(b, neg)), []int{5, 6, -5, -6}},
+		"Reversed":   {readonly.Reversed[int](a), []int{4, 3, 2, 1, 0}},
+		"Strided 1":  {readonly.Strided[int](a, 1), []int{0, 1, 2, 3, 4}},
+		"Strided 2":  {readonly.Strided[int](a, 2), []int{0, 2, 4}},
+		"Strided 5":  {readonly.Strided[int](a, 5), []int{0}},
+		"Strided 9":  {readonly.Strided[int](b, 9), []int{5}},
+		"Zip":        {readonly.Mapped(readonly.Zip[int, int](a, b), sum), []int{5, 7}},
+		"Composed":   {readonly.Reversed[int](readonly.Strided[int](readonly.Concat[int](a, b), 3)), []int{6, 3, 0}},
+	} {
+		if c.v.Len() != len(c.expected) {
+			t.Fatalf("%s: expected length %d, got %d", name, len(c.expected), c.v.Len())
+		}
+		for i, expected := range c.expected {
+			if actual := c.v.Get(i); actual != expected {
+				t.Fatalf("%s: expected %d at %d, got %d", name, expected, i, actual)
+			}
+		}
+		if actual := readonly.Materialize(c.v).Copy(); fmt.Sprint(actual) != fmt.Sprint(c.expected) {
+			t.Fatalf("%s: expected %v, got %v", name, c.expected, actual)
+		}
+
+		var calls int
+		c.v.Range(func(int, int) bool { calls++; return false })
+		c.v.Range(nil) // do nothing.
+		if calls > 1 {
+			t.Fatalf("%s: Range must stop after false, got %d calls", name, calls)
+		}
+	}
+}
+
+func sum(p readonly.Pair[int, int]) int { return p.First + p.Second }
+
+func neg(v int) int { return -v }
+
+var sink int
+
+func TestViews_Allocs(t *testing.T) {
+	a, b := readonly.NewSlice([]int{0, 1, 2, 3, 4}), readonly.NewSlice([]int{5, 6})
+	add := func(_, v int) bool { sink += v; return true }
+
+	for name, f := range map[string]func(){
+		"Mapped":   func() { v := readonly.Mapped(a, neg); sink = v.Len() + v.Get(1); v.Range(add) },
+		"Concat":   func() { v := readonly.Concat[int](a, b); sink = v.Len() + v.Get(6); v.Range(add) },
+		"Reversed": func() { v := readonly.Reversed[int](a); sink = v.Len() + v.Get(1); v.Range(add) },
+		"Strided":  func() { v := readonly.Strided[int](a, 2); sink = v.Len() + v.Get(1); v.Range(add) },
+		"Zip": func() {
+			v := readonly.Zip[int, int](a, b)
+			sink = v.Len() + v.Get(1).First
+			v.Range(func(int, readonly.Pair[int, int]) bool { return true })
+		},
+		"Composed": func() {
+			c := readonly.Concat[int](a, readonly.Mapped(b, neg))
+			v := readonly.Mapped(readonly.Zip[int, int](readonly.Reversed[int](a), readonly.Strided[int](c, 2)), sum)
+			sink = v.Len() + v.Get(1)
+			v.Range(add)
+		},
+	} {
+		if n := testing.AllocsPerRun(100, f); n != 0 {
+			t.Fatalf("%s: expected no allocations, got %v", name, n)
+		}
+	}
+}
+
+func BenchmarkMapped(b *testing.B) {
+	s := readonly.NewSlice(make([]int, limit))
+
+	b.Run("Mapped", func(b *testing.B) {
+		b.ReportAllocs()
+		v := readonly.Mapped(s, func(v int) int { return v * 2 })
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var count int
+			for j := 0; j < v.Len(); j++ {
+				count += v.Get(j)
+			}
+		}
+	})
+	b.Run("copy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m := make([]int, s.Len())
+			for j := range m {
+				m[j] = s.Get(j) * 2
+			}
+			var count int
+			for j := range m {
+				count += m[j]
+			}
+		}
+	})
+}